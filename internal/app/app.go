@@ -17,10 +17,17 @@ import (
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/command/commandimpl"
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram"
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram/api_adapter"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram/budget"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram/cache"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram/composite"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram/http_adapter"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram/scraperprofile"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/leader"
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/parser"
 	paserimpl "github.com/orgball2608/insta-parser-telegram-bot/internal/parser/parserimpl"
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/ratelimit"
 	repositories "github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/fx"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/scheduler"
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/telegram"
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/telegram/telegramimpl"
 	"github.com/orgball2608/insta-parser-telegram-bot/pkg/config"
@@ -38,6 +45,7 @@ var Module = fx.Options(
 		pgx.New,
 		newHTTPServer,
 		api_adapter.NewPlaywrightManager,
+		scraperprofile.NewStore,
 		// Rate limiter provider
 		func() ratelimit.Limiter {
 			// Allow 1 heavy command every 10 seconds, with a burst of 2 commands
@@ -50,8 +58,25 @@ var Module = fx.Options(
 			fx.As(new(telegram.Client)),
 		),
 		fx.Annotate(
-			api_adapter.New,
+			api_adapter.NewProvider,
+			fx.ResultTags(`group:"instagram_providers"`),
+		),
+		fx.Annotate(
+			http_adapter.NewProvider,
+			fx.ResultTags(`group:"instagram_providers"`),
+		),
+		fx.Annotate(
+			composite.New,
 			fx.As(new(instagram.Client)),
+			fx.As(new(instagram.HealthReporter)),
+		),
+		fx.Annotate(
+			leader.NewAdvisoryLockElector,
+			fx.As(new(leader.Elector)),
+		),
+		fx.Annotate(
+			scheduler.NewGocronScheduler,
+			fx.As(new(scheduler.Scheduler)),
 		),
 		fx.Annotate(
 			paserimpl.New,
@@ -62,25 +87,34 @@ var Module = fx.Options(
 			fx.As(new(command.Client)),
 		),
 	),
+	fx.Decorate(decorateInstagramClient),
 	repositories.Module,
 	fx.Invoke(runMigrations),
 	fx.Invoke(registerHTTPRoutes),
 	fx.Invoke(startServices),
 )
 
+// decorateInstagramClient puts the scrape cache and the shared concurrency budget in front of
+// the providers. The cache comes first, so cached and merged calls don't take a slot.
+func decorateInstagramClient(client instagram.Client, cfg *config.Config, log logger.Logger) instagram.Client {
+	return cache.New(budget.New(client, cfg, log), cfg, log)
+}
+
 type HTTPServer struct {
-	server *http.Server
-	log    logger.Logger
+	server  *http.Server
+	log     logger.Logger
+	elector leader.Elector
 }
 
-func newHTTPServer(log logger.Logger, cfg *config.Config) *HTTPServer {
+func newHTTPServer(log logger.Logger, cfg *config.Config, elector leader.Elector) *HTTPServer {
 	return &HTTPServer{
 		server: &http.Server{
 			Addr:         fmt.Sprintf(":%d", cfg.App.Port),
 			ReadTimeout:  cfg.App.Timeout,
 			WriteTimeout: cfg.App.Timeout,
 		},
-		log: log,
+		log:     log,
+		elector: elector,
 	}
 }
 
@@ -94,7 +128,7 @@ func (s *HTTPServer) healthCheckHandler(w http.ResponseWriter, r *http.Request)
 	s.log.Info("Health check request received", "method", r.Method, "url", r.URL.String())
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write([]byte(`{"status":"ok"}`))
+	_, _ = fmt.Fprintf(w, `{"status":"ok","role":%q}`, s.elector.Role())
 }
 
 func runMigrations(log logger.Logger, cfg *config.Config) error {
@@ -130,6 +164,7 @@ func startServices(
 	server *HTTPServer,
 	cmdClient command.Client,
 	pClient parser.Client,
+	elector leader.Elector,
 ) {
 	g, gCtx := errgroup.WithContext(context.Background())
 
@@ -155,22 +190,15 @@ func startServices(
 				return nil
 			})
 
+			// The queue is safe to drain from every replica
 			g.Go(func() error {
-				return cmdClient.HandleCommand(gCtx)
+				return pClient.RunScrapeWorkers(gCtx)
 			})
 
 			g.Go(func() error {
-				return pClient.ScheduleParseStories(gCtx)
-			})
-
-			g.Go(func() error {
-				log.Info("Starting database cleanup scheduler")
-				return pClient.ScheduleDatabaseCleanup(gCtx)
-			})
-
-			g.Go(func() error {
-				log.Info("Starting post checking scheduler")
-				return pClient.SchedulePostChecking(gCtx)
+				return elector.Run(gCtx, func(leaderCtx context.Context) error {
+					return runLeaderServices(leaderCtx, log, cmdClient, pClient)
+				})
 			})
 
 			// Goroutine to wait for the first service to fail and initiate shutdown
@@ -190,3 +218,54 @@ func startServices(
 		},
 	})
 }
+
+// runLeaderServices runs the services that only one replica may run: the Telegram long polling
+// and the schedulers. They stop when ctx is cancelled on losing leadership.
+func runLeaderServices(ctx context.Context, log logger.Logger, cmdClient command.Client, pClient parser.Client) error {
+	g, gCtx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		return cmdClient.HandleCommand(gCtx)
+	})
+
+	g.Go(func() error {
+		return pClient.ScheduleParseStories(gCtx)
+	})
+
+	g.Go(func() error {
+		log.Info("Starting database cleanup scheduler")
+		return pClient.ScheduleDatabaseCleanup(gCtx)
+	})
+
+	g.Go(func() error {
+		log.Info("Starting post checking scheduler")
+		return pClient.SchedulePostChecking(gCtx)
+	})
+
+	g.Go(func() error {
+		log.Info("Starting reel checking scheduler")
+		return pClient.ScheduleReelChecking(gCtx)
+	})
+
+	g.Go(func() error {
+		log.Info("Starting profile checking scheduler")
+		return pClient.ScheduleProfileChecking(gCtx)
+	})
+
+	g.Go(func() error {
+		log.Info("Starting highlight checking scheduler")
+		return pClient.ScheduleHighlightChecking(gCtx)
+	})
+
+	g.Go(func() error {
+		log.Info("Starting outbox dispatcher")
+		return pClient.ScheduleOutboxDispatch(gCtx)
+	})
+
+	g.Go(func() error {
+		log.Info("Starting scraper canary scheduler")
+		return pClient.ScheduleCanary(gCtx)
+	})
+
+	return g.Wait()
+}
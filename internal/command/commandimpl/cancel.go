@@ -0,0 +1,87 @@
+package commandimpl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram/budget"
+)
+
+// runningCommands tracks the cancel functions of the scraping commands in flight per chat,
+// so /cancel can abort them.
+type runningCommands struct {
+	mu     sync.Mutex
+	nextID uint64
+	byChat map[int64]map[uint64]context.CancelFunc
+}
+
+func newRunningCommands() *runningCommands {
+	return &runningCommands{byChat: make(map[int64]map[uint64]context.CancelFunc)}
+}
+
+// start derives a cancellable context bounded by timeout and registers it for chatID. The
+// context gets interactive priority in the scraper budget. The returned function must be
+// called once the command is finished.
+func (r *runningCommands) start(ctx context.Context, chatID int64, timeout time.Duration) (context.Context, func()) {
+	cmdCtx, cancel := context.WithTimeout(budget.WithPriority(ctx, budget.PriorityInteractive), timeout)
+
+	r.mu.Lock()
+	r.nextID++
+	id := r.nextID
+	if r.byChat[chatID] == nil {
+		r.byChat[chatID] = make(map[uint64]context.CancelFunc)
+	}
+	r.byChat[chatID][id] = cancel
+	r.mu.Unlock()
+
+	return cmdCtx, func() {
+		cancel()
+
+		r.mu.Lock()
+		delete(r.byChat[chatID], id)
+		if len(r.byChat[chatID]) == 0 {
+			delete(r.byChat, chatID)
+		}
+		r.mu.Unlock()
+	}
+}
+
+// cancelAll cancels every running command of chatID and returns how many were cancelled.
+func (r *runningCommands) cancelAll(chatID int64) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cancels := r.byChat[chatID]
+	for _, cancel := range cancels {
+		cancel()
+	}
+	delete(r.byChat, chatID)
+
+	return len(cancels)
+}
+
+func (c *CommandImpl) handleCancel(chatID int64) {
+	cancelled := c.running.cancelAll(chatID)
+	if cancelled == 0 {
+		c.Telegram.SendMessage(chatID, "There is nothing to cancel.")
+		return
+	}
+
+	c.Logger.Info("Cancelled running commands", "chat_id", chatID, "count", cancelled)
+	c.Telegram.SendMessage(chatID, fmt.Sprintf("🛑 Cancelled %d running command(s).", cancelled))
+}
+
+// abortedMessage returns the user-facing text for a command stopped by /cancel or its timeout.
+func abortedMessage(err error) (string, bool) {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "🛑 Cancelled.", true
+	case errors.Is(err, context.DeadlineExceeded):
+		return "⌛ The request took too long and was stopped. Please try again later.", true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,34 @@
+package commandimpl
+
+import (
+	"fmt"
+
+	apperrors "github.com/orgball2608/insta-parser-telegram-bot/pkg/errors"
+)
+
+// scrapeErrorMessage returns the user-facing text for a failed scrape of what, e.g. "stories
+// for @user". The raw error is left to the logs, since it means nothing to the user.
+func scrapeErrorMessage(err error, what string) string {
+	if msg, ok := abortedMessage(err); ok {
+		return msg
+	}
+
+	switch apperrors.GetCode(err) {
+	case apperrors.CodePrivate:
+		return fmt.Sprintf("🔒 The account is private, I cannot fetch %s.", what)
+	case apperrors.CodeNotFound:
+		return fmt.Sprintf("🔍 I could not find %s. Please check the username or link.", what)
+	case apperrors.CodeRateLimited:
+		return "🐢 The scraper site is limiting my requests right now. Please try again in a few minutes."
+	case apperrors.CodeSelectorMissing:
+		return fmt.Sprintf("🛠 The scraper site has changed and I could not read %s. Please try again later.", what)
+	case apperrors.CodeTimeout:
+		return fmt.Sprintf("⌛ The scraper site took too long to return %s. Please try again later.", what)
+	case apperrors.CodeEmptyResult:
+		return fmt.Sprintf("📭 The scraper site returned nothing for %s.", what)
+	case apperrors.CodeProviderDown:
+		return "🚧 The scraper site is unavailable right now. Please try again later."
+	default:
+		return fmt.Sprintf("❌ Something went wrong while fetching %s. Please try again later.", what)
+	}
+}
@@ -7,9 +7,16 @@ import (
 
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/command"
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram/budget"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram/scraperprofile"
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/parser"
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/ratelimit"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/accountpoll"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/highlightalbum"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/outbox"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/scrapejob"
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/subscription"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/scheduler"
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/telegram"
 	"github.com/orgball2608/insta-parser-telegram-bot/pkg/config"
 	"github.com/orgball2608/insta-parser-telegram-bot/pkg/logger"
@@ -20,39 +27,68 @@ import (
 type Opts struct {
 	fx.In
 
-	Instagram        instagram.Client
-	Telegram         telegram.Client
-	Parser           parser.Client
-	Logger           logger.Logger
-	Config           *config.Config
-	SubscriptionRepo subscription.Repository
-	RateLimiter      ratelimit.Limiter
+	Instagram          instagram.Client
+	Telegram           telegram.Client
+	Parser             parser.Client
+	Logger             logger.Logger
+	Config             *config.Config
+	SubscriptionRepo   subscription.Repository
+	HighlightAlbumRepo highlightalbum.Repository
+	OutboxRepo         outbox.Repository
+	ScrapeJobRepo      scrapejob.Repository
+	AccountPollRepo    accountpoll.Repository
+	RateLimiter        ratelimit.Limiter
+	ProviderHealth     instagram.HealthReporter
+	ScraperProfiles    *scraperprofile.Store
+	Scheduler          scheduler.Scheduler
 }
 
 type CommandImpl struct {
-	Instagram        instagram.Client
-	Telegram         telegram.Client
-	Parser           parser.Client
-	Logger           logger.Logger
-	Config           *config.Config
-	SubscriptionRepo subscription.Repository
-	RateLimiter      ratelimit.Limiter
+	Instagram          instagram.Client
+	Telegram           telegram.Client
+	Parser             parser.Client
+	Logger             logger.Logger
+	Config             *config.Config
+	SubscriptionRepo   subscription.Repository
+	HighlightAlbumRepo highlightalbum.Repository
+	OutboxRepo         outbox.Repository
+	ScrapeJobRepo      scrapejob.Repository
+	AccountPollRepo    accountpoll.Repository
+	RateLimiter        ratelimit.Limiter
+	ProviderHealth     instagram.HealthReporter
+	ScraperProfiles    *scraperprofile.Store
+	Scheduler          scheduler.Scheduler
+
+	running *runningCommands
 }
 
 func New(opts Opts) *CommandImpl {
 	return &CommandImpl{
-		Instagram:        opts.Instagram,
-		Telegram:         opts.Telegram,
-		Parser:           opts.Parser,
-		Logger:           opts.Logger,
-		Config:           opts.Config,
-		SubscriptionRepo: opts.SubscriptionRepo,
-		RateLimiter:      opts.RateLimiter,
+		Instagram:          opts.Instagram,
+		Telegram:           opts.Telegram,
+		Parser:             opts.Parser,
+		Logger:             opts.Logger,
+		Config:             opts.Config,
+		SubscriptionRepo:   opts.SubscriptionRepo,
+		HighlightAlbumRepo: opts.HighlightAlbumRepo,
+		OutboxRepo:         opts.OutboxRepo,
+		ScrapeJobRepo:      opts.ScrapeJobRepo,
+		AccountPollRepo:    opts.AccountPollRepo,
+		RateLimiter:        opts.RateLimiter,
+		ProviderHealth:     opts.ProviderHealth,
+		ScraperProfiles:    opts.ScraperProfiles,
+		Scheduler:          opts.Scheduler,
+		running:            newRunningCommands(),
 	}
 }
 
 var _ command.Client = (*CommandImpl)(nil)
 
+// isAdmin reports whether the chat belongs to the operator configured in TELEGRAM_USER.
+func (c *CommandImpl) isAdmin(chatID int64) bool {
+	return c.Config.Telegram.User != 0 && chatID == c.Config.Telegram.User
+}
+
 func (c *CommandImpl) doWithRetryNotify(
 	ctx context.Context,
 	chatID int64,
@@ -79,5 +115,18 @@ func (c *CommandImpl) doWithRetryNotify(
 		)
 	}
 
+	ctx = c.withQueueNotify(ctx, chatID, messageID, initialMessage)
 	return retry.RetryWithCustomNotify(ctx, operationName, operation, retry.DefaultConfig(), notifyFunc)
 }
+
+// withQueueNotify shows the command's place in the scraper queue on its status message while
+// it waits for a free slot.
+func (c *CommandImpl) withQueueNotify(ctx context.Context, chatID int64, messageID int, initialMessage string) context.Context {
+	return budget.WithQueueObserver(ctx, func(position int) {
+		c.Telegram.EditMessageText(
+			chatID,
+			messageID,
+			fmt.Sprintf("%s\n\n_Waiting for a free scraper slot, position %d in the queue..._", initialMessage, position),
+		)
+	})
+}
@@ -0,0 +1,125 @@
+package commandimpl
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/scheduler"
+)
+
+// handleJobs lists the periodic jobs with their last and next run.
+func (c *CommandImpl) handleJobs(chatID int64) {
+	if !c.isAdmin(chatID) {
+		c.Telegram.SendMessage(chatID, "This command is only available to the bot administrator.")
+		return
+	}
+
+	jobs := c.Scheduler.Jobs()
+	if len(jobs) == 0 {
+		c.Telegram.SendMessage(chatID, "No jobs are registered.")
+		return
+	}
+
+	var builder strings.Builder
+	builder.WriteString("⏱ Scheduled jobs:\n")
+	for _, job := range jobs {
+		icon := "✅"
+		state := "scheduled"
+		switch {
+		case job.Running:
+			icon, state = "🔄", "running"
+		case job.Paused:
+			icon, state = "⏸", "paused"
+		case !job.Scheduled:
+			icon, state = "⛔", "not scheduled"
+		}
+
+		builder.WriteString(fmt.Sprintf("\n%s %s - %s\n", icon, job.Name, state))
+		if job.LastRun.IsZero() {
+			builder.WriteString("   last run: never\n")
+		} else {
+			builder.WriteString(fmt.Sprintf("   last run: %s ago, took %s\n",
+				time.Since(job.LastRun).Round(time.Second), job.LastDuration.Round(time.Millisecond)))
+		}
+		if !job.NextRun.IsZero() {
+			builder.WriteString(fmt.Sprintf("   next run: in %s\n", time.Until(job.NextRun).Round(time.Second)))
+		}
+		if job.LastError != "" {
+			lastError := job.LastError
+			if len(lastError) > 200 {
+				lastError = lastError[:197] + "..."
+			}
+			builder.WriteString(fmt.Sprintf("   last error: %s\n", lastError))
+		}
+	}
+
+	c.Telegram.SendMessage(chatID, builder.String())
+}
+
+// handleRunJob starts a run of a job right away.
+func (c *CommandImpl) handleRunJob(chatID int64, args string) {
+	if !c.isAdmin(chatID) {
+		c.Telegram.SendMessage(chatID, "This command is only available to the bot administrator.")
+		return
+	}
+
+	name := strings.TrimSpace(args)
+	if name == "" {
+		c.Telegram.SendMessage(chatID, "Please provide a job name: /runjob <name>. Use /jobs to see them.")
+		return
+	}
+
+	if err := c.Scheduler.RunNow(name); err != nil {
+		c.Telegram.SendMessage(chatID, jobErrorMessage(name, err))
+		return
+	}
+
+	c.Telegram.SendMessage(chatID, fmt.Sprintf("▶️ Job %s started. Use /jobs to follow it.", name))
+}
+
+// handlePauseJob pauses the scheduled runs of a job, or resumes them if it is paused.
+func (c *CommandImpl) handlePauseJob(chatID int64, args string) {
+	if !c.isAdmin(chatID) {
+		c.Telegram.SendMessage(chatID, "This command is only available to the bot administrator.")
+		return
+	}
+
+	name := strings.TrimSpace(args)
+	if name == "" {
+		c.Telegram.SendMessage(chatID, "Please provide a job name: /pausejob <name>. Use /jobs to see them.")
+		return
+	}
+
+	paused := false
+	for _, job := range c.Scheduler.Jobs() {
+		if job.Name == name {
+			paused = job.Paused
+		}
+	}
+
+	if err := c.Scheduler.SetPaused(name, !paused); err != nil {
+		c.Telegram.SendMessage(chatID, jobErrorMessage(name, err))
+		return
+	}
+
+	if paused {
+		c.Telegram.SendMessage(chatID, fmt.Sprintf("▶️ Job %s resumed.", name))
+		return
+	}
+	c.Telegram.SendMessage(chatID, fmt.Sprintf("⏸ Job %s paused. Send /pausejob %s again to resume it.", name, name))
+}
+
+func jobErrorMessage(name string, err error) string {
+	switch {
+	case errors.Is(err, scheduler.ErrJobNotFound):
+		return fmt.Sprintf("No job named %q. Use /jobs to see them.", name)
+	case errors.Is(err, scheduler.ErrJobRunning):
+		return fmt.Sprintf("Job %s is already running.", name)
+	case errors.Is(err, scheduler.ErrJobNotScheduled):
+		return fmt.Sprintf("Job %s is not scheduled right now.", name)
+	default:
+		return fmt.Sprintf("❌ Failed to control job %s: %v", name, err)
+	}
+}
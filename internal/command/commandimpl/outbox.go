@@ -0,0 +1,92 @@
+package commandimpl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/outbox"
+)
+
+// maxListedDeadMessages caps the dead-lettered notifications shown by /outbox.
+const maxListedDeadMessages = 10
+
+// handleOutbox shows the notification outbox to the administrator: the number of messages
+// in each state and the latest dead-lettered ones. "/outbox retry <id>" requeues a dead one.
+func (c *CommandImpl) handleOutbox(ctx context.Context, chatID int64, args string) {
+	if !c.isAdmin(chatID) {
+		c.Telegram.SendMessage(chatID, "This command is only available to the bot administrator.")
+		return
+	}
+
+	fields := strings.Fields(args)
+	if len(fields) > 0 {
+		if fields[0] != "retry" || len(fields) != 2 {
+			c.Telegram.SendMessage(chatID, "Usage: /outbox or /outbox retry <id>")
+			return
+		}
+		c.handleOutboxRetry(ctx, chatID, fields[1])
+		return
+	}
+
+	counts, err := c.OutboxRepo.CountByStatus(ctx)
+	if err != nil {
+		c.Logger.Error("Failed to count outbox messages", "error", err)
+		c.Telegram.SendMessage(chatID, "❌ Failed to read the outbox.")
+		return
+	}
+
+	dead, err := c.OutboxRepo.GetDead(ctx, maxListedDeadMessages)
+	if err != nil {
+		c.Logger.Error("Failed to get dead outbox messages", "error", err)
+		c.Telegram.SendMessage(chatID, "❌ Failed to read the outbox.")
+		return
+	}
+
+	var builder strings.Builder
+	builder.WriteString("📮 Notification outbox\n\n")
+	builder.WriteString(fmt.Sprintf("Pending: %d\nSent: %d\nDead: %d\n",
+		counts[domain.OutboxStatusPending],
+		counts[domain.OutboxStatusSent],
+		counts[domain.OutboxStatusDead]))
+
+	if len(dead) > 0 {
+		builder.WriteString("\nLatest dead messages:\n")
+		for _, msg := range dead {
+			lastError := msg.LastError
+			if len(lastError) > 200 {
+				lastError = lastError[:197] + "..."
+			}
+			builder.WriteString(fmt.Sprintf("\n#%d %s %s → %d\n   %d attempts, last %s ago: %s\n",
+				msg.ID, msg.ContentType, msg.ContentID, msg.ChatID,
+				msg.Attempts, time.Since(msg.UpdatedAt).Round(time.Second), lastError))
+		}
+		builder.WriteString("\nUse /outbox retry <id> to send one again.")
+	}
+
+	c.Telegram.SendMessage(chatID, builder.String())
+}
+
+func (c *CommandImpl) handleOutboxRetry(ctx context.Context, chatID int64, arg string) {
+	id, err := strconv.Atoi(strings.TrimPrefix(arg, "#"))
+	if err != nil {
+		c.Telegram.SendMessage(chatID, "Please provide the numeric ID of a dead message: /outbox retry <id>")
+		return
+	}
+
+	if err := c.OutboxRepo.Requeue(ctx, id); err != nil {
+		if errors.Is(err, outbox.ErrNotFound) {
+			c.Telegram.SendMessage(chatID, fmt.Sprintf("No dead outbox message with ID %d.", id))
+			return
+		}
+		c.Logger.Error("Failed to requeue outbox message", "id", id, "error", err)
+		c.Telegram.SendMessage(chatID, "❌ Failed to requeue the message.")
+		return
+	}
+
+	c.Telegram.SendMessage(chatID, fmt.Sprintf("✅ Message #%d was requeued and will be sent on the next dispatch.", id))
+}
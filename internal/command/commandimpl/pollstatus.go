@@ -0,0 +1,73 @@
+package commandimpl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+)
+
+// maxListedPolls caps the accounts shown by /pollstatus.
+const maxListedPolls = 40
+
+// handlePollStatus shows the poll interval and the last and next poll of each account, soonest
+// first. An optional username narrows the list to that account.
+func (c *CommandImpl) handlePollStatus(ctx context.Context, chatID int64, args string) {
+	if !c.isAdmin(chatID) {
+		c.Telegram.SendMessage(chatID, "This command is only available to the bot administrator.")
+		return
+	}
+
+	polls, err := c.AccountPollRepo.GetAll(ctx)
+	if err != nil {
+		c.Logger.Error("Failed to get account polls", "error", err)
+		c.Telegram.SendMessage(chatID, "❌ Failed to get the poll status.")
+		return
+	}
+
+	username := strings.TrimPrefix(strings.TrimSpace(args), "@")
+	if username != "" {
+		var filtered []*domain.AccountPoll
+		for _, poll := range polls {
+			if strings.EqualFold(poll.Username, username) {
+				filtered = append(filtered, poll)
+			}
+		}
+		polls = filtered
+	}
+
+	if len(polls) == 0 {
+		if username != "" {
+			c.Telegram.SendMessage(chatID, fmt.Sprintf("@%s is not polled yet.", username))
+			return
+		}
+		c.Telegram.SendMessage(chatID, "No accounts are polled yet.")
+		return
+	}
+
+	var builder strings.Builder
+	builder.WriteString("📡 Account polling:\n")
+	for i, poll := range polls {
+		if i == maxListedPolls {
+			builder.WriteString(fmt.Sprintf("\n...and %d more. Use /pollstatus <username> to see one account.\n", len(polls)-i))
+			break
+		}
+
+		builder.WriteString(fmt.Sprintf("\n@%s (%s) - every %s, %d items in the last 4 days\n",
+			poll.Username, poll.JobType, poll.Interval.Round(time.Minute), poll.ItemsSeen))
+		if poll.LastPolledAt == nil {
+			builder.WriteString("   last poll: never\n")
+		} else {
+			builder.WriteString(fmt.Sprintf("   last poll: %s ago\n", time.Since(*poll.LastPolledAt).Round(time.Second)))
+		}
+		if until := time.Until(poll.NextPollAt); until > 0 {
+			builder.WriteString(fmt.Sprintf("   next poll: in %s\n", until.Round(time.Second)))
+		} else {
+			builder.WriteString("   next poll: due\n")
+		}
+	}
+
+	c.Telegram.SendMessage(chatID, builder.String())
+}
@@ -4,15 +4,17 @@ import (
 	"context"
 	"fmt"
 	"strings"
-	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
 	"github.com/orgball2608/insta-parser-telegram-bot/pkg/formatter"
 )
 
+// maxMediaCaptionLength is the longest caption Telegram accepts on a photo, video or album.
+const maxMediaCaptionLength = 1024
+
 func (c *CommandImpl) handlePostCommand(ctx context.Context, update tgbotapi.Update) error {
-	postURL := strings.TrimSpace(update.Message.CommandArguments())
+	postURL, _ := splitRefresh(update.Message.CommandArguments())
 	chatID := update.Message.Chat.ID
 
 	if postURL == "" {
@@ -27,25 +29,22 @@ func (c *CommandImpl) handlePostCommand(ctx context.Context, update tgbotapi.Upd
 		return fmt.Errorf("failed to send initial message: %w", err)
 	}
 
-	ctxWithTimeout, cancel := context.WithTimeout(ctx, 5*time.Minute)
-	defer cancel()
-
 	var post *domain.PostItem
 
 	op := func() error {
 		var opErr error
-		post, opErr = c.Instagram.GetUserPost(ctxWithTimeout, postURL)
+		post, opErr = c.Instagram.GetUserPost(ctx, postURL)
 		return opErr
 	}
 
 	err = c.doWithRetryNotify(ctx, chatID, sentMsgID, initialMessage, "GetUserPost", op)
 
 	if err != nil {
-		c.Telegram.EditMessageText(chatID, sentMsgID, fmt.Sprintf("❌ Error fetching post: %v", err))
+		c.Telegram.EditMessageText(chatID, sentMsgID, scrapeErrorMessage(err, "this post"))
 		return fmt.Errorf("failed to get post from URL: %w", err)
 	}
 
-	if len(post.MediaURLs) == 0 {
+	if len(post.Media) == 0 {
 		c.Telegram.EditMessageText(chatID, sentMsgID, "Could not find any media in the provided URL.")
 		return nil
 	}
@@ -54,7 +53,6 @@ func (c *CommandImpl) handlePostCommand(ctx context.Context, update tgbotapi.Upd
 
 	c.Telegram.EditMessageText(chatID, sentMsgID, "✅ Successfully fetched post info! Sending media now...")
 
-	mediaGroup := make([]interface{}, 0, len(post.MediaURLs))
 	var captionBuilder strings.Builder
 	if post.Username != "" {
 		escapedUsername := formatter.EscapeMarkdownV2(post.Username)
@@ -79,37 +77,25 @@ func (c *CommandImpl) handlePostCommand(ctx context.Context, update tgbotapi.Upd
 
 	captionToSend := captionBuilder.String()
 
-	for i, mediaURL := range post.MediaURLs {
-		var mediaItem tgbotapi.RequestFileData = tgbotapi.FileURL(mediaURL)
-		var media tgbotapi.BaseInputMedia
-
-		if strings.Contains(mediaURL, ".mp4") {
-			video := tgbotapi.NewInputMediaVideo(mediaItem)
-			if i == 0 {
-				video.Caption = captionToSend
-			}
-			media = video.BaseInputMedia
-		} else {
-			photo := tgbotapi.NewInputMediaPhoto(mediaItem)
-			if i == 0 {
-				photo.Caption = captionToSend
-			}
-			media = photo.BaseInputMedia
-		}
-		mediaGroup = append(mediaGroup, media)
+	// Telegram limits media captions, so long captions follow as a separate message
+	albumCaption := captionToSend
+	if len([]rune(captionToSend)) > maxMediaCaptionLength {
+		albumCaption = ""
 	}
 
-	if len(mediaGroup) > 0 {
-		if err := c.Telegram.SendMediaGroup(chatID, mediaGroup); err != nil {
-			c.Logger.Error("Failed to send media group, falling back to individual sending", "error", err)
+	if err := c.Telegram.SendMediaAlbum(chatID, post.Media, albumCaption); err != nil {
+		c.Logger.Error("Failed to send media album, falling back to individual sending", "error", err)
 
-			if captionToSend != "" {
-				c.Telegram.SendMessage(chatID, captionToSend)
-			}
-			for _, mediaURL := range post.MediaURLs {
-				c.Telegram.SendMediaByUrl(chatID, mediaURL)
+		if captionToSend != "" {
+			c.Telegram.SendMessage(chatID, captionToSend)
+		}
+		for _, item := range post.Media {
+			if _, err := c.Telegram.SendMedia(chatID, item, ""); err != nil {
+				c.Logger.Error("Failed to send post media", "url", item.URL, "error", err)
 			}
 		}
+	} else if albumCaption == "" && captionToSend != "" {
+		c.Telegram.SendMessage(chatID, captionToSend)
 	}
 
 	return nil
@@ -0,0 +1,90 @@
+package commandimpl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/formatter"
+)
+
+func (c *CommandImpl) handleProfileCommand(ctx context.Context, update tgbotapi.Update) error {
+	userName, _ := splitRefresh(update.Message.CommandArguments())
+	userName = strings.TrimPrefix(userName, "@")
+	chatID := update.Message.Chat.ID
+
+	if userName == "" {
+		_, err := c.Telegram.SendMessage(chatID, "Please provide a username: /profile <username>")
+		return err
+	}
+
+	escapedUser := formatter.EscapeMarkdownV2(userName)
+	initialMessage := fmt.Sprintf("Fetching profile of @%s... ⏳", escapedUser)
+	sentMsgID, err := c.Telegram.SendMessage(chatID, initialMessage)
+	if err != nil {
+		return fmt.Errorf("failed to send initial message: %w", err)
+	}
+
+	var profile *domain.UserProfile
+	op := func() error {
+		var opErr error
+		profile, opErr = c.Instagram.GetUserProfile(ctx, userName)
+		return opErr
+	}
+
+	err = c.doWithRetryNotify(ctx, chatID, sentMsgID, initialMessage, "GetUserProfile", op)
+	if err != nil {
+		c.Telegram.EditMessageText(chatID, sentMsgID, scrapeErrorMessage(err, "the profile of @"+escapedUser))
+		return err
+	}
+
+	// The caption is plain text: bios are full of characters Markdown would trip over
+	caption := profileCaption(profile)
+
+	c.Telegram.EditMessageText(chatID, sentMsgID, fmt.Sprintf("✅ Found @%s.", escapedUser))
+
+	if profile.AvatarURL == "" {
+		_, err = c.Telegram.SendMessage(chatID, caption)
+		return err
+	}
+
+	avatar := domain.NewMediaItem(domain.MediaTypeImage, []domain.MediaResolution{{URL: profile.AvatarURL}})
+	if len([]rune(caption)) > maxMediaCaptionLength {
+		if _, err := c.Telegram.SendMedia(chatID, avatar, ""); err != nil {
+			c.Logger.Error("Failed to send profile avatar", "user", userName, "error", err)
+		}
+		_, err = c.Telegram.SendMessage(chatID, caption)
+		return err
+	}
+
+	if _, err := c.Telegram.SendMedia(chatID, avatar, caption); err != nil {
+		c.Logger.Error("Failed to send profile avatar", "user", userName, "error", err)
+		_, err = c.Telegram.SendMessage(chatID, caption)
+		return err
+	}
+	return nil
+}
+
+// profileCaption builds the plain-text summary of an account sent with its avatar.
+func profileCaption(profile *domain.UserProfile) string {
+	var sb strings.Builder
+	if profile.FullName != "" {
+		sb.WriteString(profile.FullName + " ")
+	}
+	sb.WriteString("@" + profile.Username)
+	if profile.IsVerified {
+		sb.WriteString(" ✔️")
+	}
+	if profile.IsPrivate {
+		sb.WriteString(" 🔒")
+	}
+	sb.WriteString("\n\n")
+	if profile.Biography != "" {
+		sb.WriteString(profile.Biography + "\n\n")
+	}
+	sb.WriteString(fmt.Sprintf("📷 %s posts · 👥 %s followers · ➡️ %s following",
+		formatter.FormatNumber(profile.PostCount), formatter.FormatNumber(profile.FollowerCount), formatter.FormatNumber(profile.FollowingCount)))
+	return sb.String()
+}
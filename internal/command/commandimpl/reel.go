@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"strings"
-	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
@@ -12,7 +11,7 @@ import (
 )
 
 func (c *CommandImpl) handleReelCommand(ctx context.Context, update tgbotapi.Update) error {
-	reelURL := strings.TrimSpace(update.Message.CommandArguments())
+	reelURL, _ := splitRefresh(update.Message.CommandArguments())
 	chatID := update.Message.Chat.ID
 
 	if reelURL == "" {
@@ -27,25 +26,22 @@ func (c *CommandImpl) handleReelCommand(ctx context.Context, update tgbotapi.Upd
 		return fmt.Errorf("failed to send initial message: %w", err)
 	}
 
-	ctxWithTimeout, cancel := context.WithTimeout(ctx, 5*time.Minute)
-	defer cancel()
-
 	var reel *domain.PostItem
 
 	op := func() error {
 		var opErr error
-		reel, opErr = c.Instagram.GetUserReel(ctxWithTimeout, reelURL)
+		reel, opErr = c.Instagram.GetUserReel(ctx, reelURL)
 		return opErr
 	}
 
 	err = c.doWithRetryNotify(ctx, chatID, sentMsgID, initialMessage, "GetUserReel", op)
 
 	if err != nil {
-		c.Telegram.EditMessageText(chatID, sentMsgID, fmt.Sprintf("❌ Error fetching Reel: %v", err))
+		c.Telegram.EditMessageText(chatID, sentMsgID, scrapeErrorMessage(err, "this Reel"))
 		return fmt.Errorf("failed to get Reel from URL: %w", err)
 	}
 
-	if len(reel.MediaURLs) == 0 {
+	if len(reel.Media) == 0 {
 		c.Telegram.EditMessageText(chatID, sentMsgID, "Could not find any media in the provided URL.")
 		return nil
 	}
@@ -78,12 +74,20 @@ func (c *CommandImpl) handleReelCommand(ctx context.Context, update tgbotapi.Upd
 
 	captionToSend := captionBuilder.String()
 
-	err = c.Telegram.SendMediaByUrl(chatID, reel.MediaURLs[0])
-	if err != nil {
+	video := reel.Media[0]
+	video.Type = domain.MediaTypeVideo
+
+	// Telegram limits media captions, so long captions follow as a separate message
+	videoCaption := captionToSend
+	if len([]rune(captionToSend)) > maxMediaCaptionLength {
+		videoCaption = ""
+	}
+
+	if _, err = c.Telegram.SendMedia(chatID, video, videoCaption); err != nil {
 		c.Logger.Error("Failed to send Reel video", "error", err)
 	}
 
-	if captionToSend != "" {
+	if videoCaption == "" && captionToSend != "" {
 		c.Telegram.SendMessage(chatID, captionToSend)
 	}
 
@@ -0,0 +1,17 @@
+package commandimpl
+
+import "strings"
+
+// refreshFlag is the trailing argument that makes a download skip cached scrape results,
+// e.g. "/story <username> refresh".
+const refreshFlag = "refresh"
+
+// splitRefresh returns the command arguments without a trailing refresh flag and whether it
+// was given.
+func splitRefresh(args string) (string, bool) {
+	fields := strings.Fields(args)
+	if len(fields) > 1 && strings.EqualFold(fields[len(fields)-1], refreshFlag) {
+		return strings.Join(fields[:len(fields)-1], " "), true
+	}
+	return strings.TrimSpace(args), false
+}
@@ -0,0 +1,76 @@
+package commandimpl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/scrapejob"
+)
+
+// maxListedFailedJobs caps the dead scrape jobs shown by /failedjobs.
+const maxListedFailedJobs = 20
+
+// handleFailedJobs lists the account checks that failed on every attempt.
+func (c *CommandImpl) handleFailedJobs(ctx context.Context, chatID int64) {
+	if !c.isAdmin(chatID) {
+		c.Telegram.SendMessage(chatID, "This command is only available to the bot administrator.")
+		return
+	}
+
+	jobs, err := c.ScrapeJobRepo.GetDeadJobs(ctx, maxListedFailedJobs)
+	if err != nil {
+		c.Logger.Error("Failed to get dead scrape jobs", "error", err)
+		c.Telegram.SendMessage(chatID, "❌ Failed to read the failed jobs.")
+		return
+	}
+
+	if len(jobs) == 0 {
+		c.Telegram.SendMessage(chatID, "✅ No failed scrape jobs.")
+		return
+	}
+
+	var builder strings.Builder
+	builder.WriteString("🪦 Failed scrape jobs (latest first):\n")
+	for _, job := range jobs {
+		lastError := job.LastError
+		if len(lastError) > 200 {
+			lastError = lastError[:197] + "..."
+		}
+		builder.WriteString(fmt.Sprintf("\n#%d %s check of @%s\n   %d attempts, failed %s ago: %s\n",
+			job.ID, job.JobType, job.Username, job.Attempts,
+			time.Since(job.FailedAt).Round(time.Second), lastError))
+	}
+	builder.WriteString("\nUse /requeue <id> to queue one again.")
+
+	c.Telegram.SendMessage(chatID, builder.String())
+}
+
+// handleRequeue moves a failed scrape job back to the queue.
+func (c *CommandImpl) handleRequeue(ctx context.Context, chatID int64, args string) {
+	if !c.isAdmin(chatID) {
+		c.Telegram.SendMessage(chatID, "This command is only available to the bot administrator.")
+		return
+	}
+
+	id, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(args), "#"))
+	if err != nil {
+		c.Telegram.SendMessage(chatID, "Please provide the numeric ID of a failed job: /requeue <id>")
+		return
+	}
+
+	if err := c.ScrapeJobRepo.Requeue(ctx, id); err != nil {
+		if errors.Is(err, scrapejob.ErrNotFound) {
+			c.Telegram.SendMessage(chatID, fmt.Sprintf("No failed scrape job with ID %d.", id))
+			return
+		}
+		c.Logger.Error("Failed to requeue scrape job", "id", id, "error", err)
+		c.Telegram.SendMessage(chatID, "❌ Failed to requeue the job.")
+		return
+	}
+
+	c.Telegram.SendMessage(chatID, fmt.Sprintf("✅ Job #%d was queued again.", id))
+}
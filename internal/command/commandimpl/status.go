@@ -0,0 +1,61 @@
+package commandimpl
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+func (c *CommandImpl) handleStatus(chatID int64) {
+	statuses := c.ProviderHealth.ProviderStatuses()
+	if len(statuses) == 0 {
+		c.Telegram.SendMessage(chatID, "No scraper providers are configured.")
+		return
+	}
+
+	// Sent as plain text, since provider errors contain arbitrary characters
+	var builder strings.Builder
+	builder.WriteString("🩺 Scraper providers (in priority order):\n")
+	for _, status := range statuses {
+		icon := "✅"
+		state := "healthy"
+		if !status.Healthy {
+			icon = "⛔"
+			state = fmt.Sprintf("cooling down for %s", time.Until(status.CooldownUntil).Round(time.Second))
+		}
+
+		builder.WriteString(fmt.Sprintf("\n%s %d. %s - %s\n", icon, status.Priority, status.Name, state))
+		if !status.LastSuccessAt.IsZero() {
+			builder.WriteString(fmt.Sprintf("   last success: %s ago\n", time.Since(status.LastSuccessAt).Round(time.Second)))
+		}
+		if status.ConsecutiveFailures > 0 {
+			builder.WriteString(fmt.Sprintf("   consecutive failures: %d\n", status.ConsecutiveFailures))
+		}
+		if status.LastError != "" && !status.LastFailureAt.IsZero() {
+			lastError := status.LastError
+			if len(lastError) > 200 {
+				lastError = lastError[:197] + "..."
+			}
+			builder.WriteString(fmt.Sprintf("   last error (%s ago): %s\n",
+				time.Since(status.LastFailureAt).Round(time.Second), lastError))
+		}
+	}
+
+	c.Telegram.SendMessage(chatID, builder.String())
+}
+
+func (c *CommandImpl) handleReloadProfile(chatID int64) {
+	if !c.isAdmin(chatID) {
+		c.Telegram.SendMessage(chatID, "This command is only available to the bot administrator.")
+		return
+	}
+
+	profile, err := c.ScraperProfiles.Reload()
+	if err != nil {
+		c.Logger.Error("Failed to reload scraper profile", "error", err)
+		c.Telegram.SendMessage(chatID, fmt.Sprintf("❌ Scraper profile rejected, the previous profile stays active:\n%v", err))
+		return
+	}
+
+	c.Telegram.SendMessage(chatID, fmt.Sprintf("✅ Scraper profile version %d reloaded.", profile.Version))
+}
@@ -5,15 +5,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"os"
 	"runtime/debug"
-	"strings"
-	"sync"
 	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram/cache"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/highlightalbum"
 	"github.com/orgball2608/insta-parser-telegram-bot/pkg/formatter"
 )
 
@@ -22,7 +21,7 @@ const helpMessage = `👋 *Welcome to the Instagram Parser Bot!*
 Here are the available commands:
 
 *AUTOMATIC SUBSCRIPTIONS:*
-/subscribe <username> - Subscribe to a user to get new stories automatically.
+/subscribe <username> [story|post|reel|highlight|profile|all] - Get new stories, posts, reels, highlights or profile changes of a user automatically.
 /unsubscribe <username> - Unsubscribe from a user.
 /listsubscriptions - List all your current subscriptions.
 
@@ -31,6 +30,12 @@ Here are the available commands:
 /highlights <username> - Fetch all highlights from a user.
 /post <post_url> - Download a post (photo/video/album) from its URL.
 /reel <reel_url> - Download a Reel from its URL.
+/profile <username> - Show an account's bio, counters and avatar.
+Results are reused for a few minutes; add "refresh" after any of these to fetch them again, e.g. /story <username> refresh.
+
+*STATUS:*
+/status - Show the health of the scraper providers.
+/cancel - Stop your running downloads.
 
 Type /help at any time to see this guide.`
 
@@ -94,6 +99,36 @@ func (c *CommandImpl) processCommand(ctx context.Context, update tgbotapi.Update
 	case "start", "help":
 		_, err := c.Telegram.SendMessage(chatID, helpMessage)
 		return err
+	case "status":
+		c.handleStatus(chatID)
+		return nil
+	case "reloadprofile":
+		c.handleReloadProfile(chatID)
+		return nil
+	case "outbox":
+		c.handleOutbox(ctx, chatID, args)
+		return nil
+	case "failedjobs":
+		c.handleFailedJobs(ctx, chatID)
+		return nil
+	case "requeue":
+		c.handleRequeue(ctx, chatID, args)
+		return nil
+	case "jobs":
+		c.handleJobs(chatID)
+		return nil
+	case "runjob":
+		c.handleRunJob(chatID, args)
+		return nil
+	case "pausejob":
+		c.handlePauseJob(chatID, args)
+		return nil
+	case "pollstatus":
+		c.handlePollStatus(ctx, chatID, args)
+		return nil
+	case "cancel":
+		c.handleCancel(chatID)
+		return nil
 	case "subscribe", "unsubscribe", "listsubscriptions":
 		// Subscription commands are lightweight, no need for rate limiting
 		switch command {
@@ -113,6 +148,14 @@ func (c *CommandImpl) processCommand(ctx context.Context, update tgbotapi.Update
 		return nil
 	}
 
+	// Heavy commands are bounded by the command timeout and can be stopped with /cancel
+	ctx, done := c.running.start(ctx, chatID, c.Config.Telegram.CommandTimeout)
+	defer done()
+
+	if _, refresh := splitRefresh(args); refresh {
+		ctx = cache.WithRefresh(ctx)
+	}
+
 	// Process heavy commands
 	switch command {
 	case "story":
@@ -123,6 +166,8 @@ func (c *CommandImpl) processCommand(ctx context.Context, update tgbotapi.Update
 		return c.handlePostCommand(ctx, update)
 	case "reel":
 		return c.handleReelCommand(ctx, update)
+	case "profile":
+		return c.handleProfileCommand(ctx, update)
 	default:
 		_, err := c.Telegram.SendMessage(chatID, "Unknown command. Type /help to see the list of available commands.")
 		return err
@@ -130,8 +175,7 @@ func (c *CommandImpl) processCommand(ctx context.Context, update tgbotapi.Update
 }
 
 func (c *CommandImpl) handleStoryCommand(ctx context.Context, update tgbotapi.Update) error {
-	args := strings.TrimSpace(update.Message.CommandArguments())
-	userName := strings.TrimSpace(args)
+	userName, _ := splitRefresh(update.Message.CommandArguments())
 	chatID := update.Message.Chat.ID
 
 	if userName == "" {
@@ -149,17 +193,13 @@ func (c *CommandImpl) handleStoryCommand(ctx context.Context, update tgbotapi.Up
 	var stories []domain.StoryItem
 	op := func() error {
 		var opErr error
-		stories, opErr = c.Instagram.GetUserStories(userName)
+		stories, opErr = c.Instagram.GetUserStories(ctx, userName)
 		return opErr
 	}
 
 	err = c.doWithRetryNotify(ctx, chatID, sentMsgID, initialMessage, "GetUserStories", op)
 	if err != nil {
-		errMsg := fmt.Sprintf("❌ Error fetching stories for @%s: %v", escapedUser, err)
-		if errors.Is(err, instagram.ErrPrivateAccount) {
-			errMsg = fmt.Sprintf("Account @%s is private, I cannot fetch stories.", escapedUser)
-		}
-		c.Telegram.EditMessageText(chatID, sentMsgID, errMsg)
+		c.Telegram.EditMessageText(chatID, sentMsgID, scrapeErrorMessage(err, "stories for @"+escapedUser))
 		return err
 	}
 
@@ -178,7 +218,7 @@ func (c *CommandImpl) handleStoryCommand(ctx context.Context, update tgbotapi.Up
 		if item.MediaURL == "" {
 			continue
 		}
-		if err := c.Telegram.SendMediaByUrl(chatID, item.MediaURL); err != nil {
+		if _, err := c.Telegram.SendMedia(chatID, item.GetMedia(), formatter.StoryCaption(userName, item.TakenAt)); err != nil {
 			c.Logger.Error("Failed to send story media", "url", item.MediaURL, "error", err)
 		}
 	}
@@ -188,7 +228,7 @@ func (c *CommandImpl) handleStoryCommand(ctx context.Context, update tgbotapi.Up
 }
 
 func (c *CommandImpl) handleHighlightsCommand(ctx context.Context, update tgbotapi.Update) error {
-	userName := strings.TrimSpace(update.Message.CommandArguments())
+	userName, _ := splitRefresh(update.Message.CommandArguments())
 	chatID := update.Message.Chat.ID
 
 	if userName == "" {
@@ -207,17 +247,13 @@ func (c *CommandImpl) handleHighlightsCommand(ctx context.Context, update tgbota
 	var previews []domain.HighlightAlbumPreview
 	op := func() error {
 		var opErr error
-		previews, opErr = c.Instagram.GetHighlightAlbumPreviews(userName)
+		previews, opErr = c.Instagram.GetHighlightAlbumPreviews(ctx, userName)
 		return opErr
 	}
 
 	err = c.doWithRetryNotify(ctx, chatID, sentMsgID, initialMessage, "GetHighlightAlbumPreviews", op)
 	if err != nil {
-		errMsg := fmt.Sprintf("❌ Error fetching highlights for @%s: %v", escapedUser, err)
-		if errors.Is(err, instagram.ErrPrivateAccount) {
-			errMsg = fmt.Sprintf("Account @%s is private, I cannot fetch highlights.", escapedUser)
-		}
-		c.Telegram.EditMessageText(chatID, sentMsgID, errMsg)
+		c.Telegram.EditMessageText(chatID, sentMsgID, scrapeErrorMessage(err, "highlights for @"+escapedUser))
 		return err
 	}
 
@@ -229,18 +265,31 @@ func (c *CommandImpl) handleHighlightsCommand(ctx context.Context, update tgbota
 	// Create inline keyboard with buttons for each album
 	var keyboardRows [][]tgbotapi.InlineKeyboardButton
 	for _, preview := range previews {
-		// Create callback data as JSON
-		callbackData, _ := json.Marshal(map[string]string{
-			"action":   "dl_highlight",
-			"user":     userName,
-			"album_id": preview.ID,
+		// Buttons refer to the stored album, since the username and album ID together can
+		// exceed Telegram's 64-byte callback data limit
+		id, err := c.HighlightAlbumRepo.Upsert(ctx, domain.HighlightAlbum{
+			Username: userName,
+			AlbumID:  preview.ID,
+			Title:    preview.Title,
+			CoverURL: preview.CoverURL,
 		})
+		if err != nil {
+			c.Logger.Error("Failed to store highlight album", "user", userName, "albumID", preview.ID, "error", err)
+			continue
+		}
+
+		callbackData, _ := json.Marshal(highlightCallbackData{Action: "dl_highlight", ID: id})
 
 		// Just use the title as button text
 		button := tgbotapi.NewInlineKeyboardButtonData(preview.Title, string(callbackData))
 		keyboardRows = append(keyboardRows, tgbotapi.NewInlineKeyboardRow(button))
 	}
 
+	if len(keyboardRows) == 0 {
+		c.Telegram.EditMessageText(chatID, sentMsgID, "❌ Could not prepare the highlight albums. Please try again later.")
+		return errors.New("no highlight album could be stored")
+	}
+
 	// Create and send the message with inline keyboard
 	msgText := fmt.Sprintf("Found %d highlight albums for @%s\nPlease select an album to download:", len(previews), escapedUser)
 	msg := tgbotapi.NewMessage(chatID, msgText)
@@ -253,6 +302,12 @@ func (c *CommandImpl) handleHighlightsCommand(ctx context.Context, update tgbota
 	return nil
 }
 
+// highlightCallbackData is the payload of a highlight album button.
+type highlightCallbackData struct {
+	Action string `json:"action"`
+	ID     int    `json:"id"` // Serial ID in the highlight_albums table
+}
+
 // New method to handle callback queries from button clicks
 func (c *CommandImpl) handleCallback(ctx context.Context, callbackQuery *tgbotapi.CallbackQuery) {
 	// Acknowledge the callback to remove the loading animation on the button
@@ -261,45 +316,56 @@ func (c *CommandImpl) handleCallback(ctx context.Context, callbackQuery *tgbotap
 	_, _ = c.Telegram.Request(callback)
 
 	// Parse the callback data
-	var callbackData struct {
-		Action  string `json:"action"`
-		User    string `json:"user"`
-		AlbumID string `json:"album_id"`
-	}
-
+	var callbackData highlightCallbackData
 	if err := json.Unmarshal([]byte(callbackQuery.Data), &callbackData); err != nil {
 		c.Logger.Error("Failed to unmarshal callback data", "error", err)
 		return
 	}
 
 	chatID := callbackQuery.Message.Chat.ID
+	messageID := callbackQuery.Message.MessageID
 
 	// Handle different callback actions
 	switch callbackData.Action {
 	case "dl_highlight":
+		album, err := c.HighlightAlbumRepo.GetByID(ctx, callbackData.ID)
+		if err != nil {
+			// Buttons from before albums were stored carry no ID
+			if !errors.Is(err, highlightalbum.ErrNotFound) {
+				c.Logger.Error("Failed to load highlight album", "id", callbackData.ID, "error", err)
+			}
+			c.Telegram.EditMessageText(chatID, messageID, "⌛ This button is out of date. Please run /highlights again.")
+			return
+		}
+
 		// Escape username to avoid Markdown parsing errors
-		escapedUser := formatter.EscapeMarkdownV2(callbackData.User)
+		escapedUser := formatter.EscapeMarkdownV2(album.Username)
 		// Update the message to show we're processing
 		c.Telegram.EditMessageText(
 			chatID,
-			callbackQuery.Message.MessageID,
+			messageID,
 			fmt.Sprintf("Downloading highlight album for @%s... ⏳", escapedUser),
 		)
 
 		// Download the selected highlight album
-		c.downloadSingleHighlightAlbum(ctx, chatID, callbackData.User, callbackData.AlbumID, callbackQuery.Message.MessageID)
+		ctx, done := c.running.start(ctx, chatID, c.Config.Telegram.CommandTimeout)
+		defer done()
+		c.downloadSingleHighlightAlbum(ctx, chatID, album.Username, album.AlbumID, messageID)
 	}
 }
 
 // New method to download a single highlight album
 func (c *CommandImpl) downloadSingleHighlightAlbum(ctx context.Context, chatID int64, userName, albumID string, messageID int) {
+	escapedUser := formatter.EscapeMarkdownV2(userName)
+	ctx = c.withQueueNotify(ctx, chatID, messageID, fmt.Sprintf("Downloading highlight album for @%s... ⏳", escapedUser))
+
 	// Get the highlight album
-	highlightReel, err := c.Instagram.GetSingleHighlightAlbum(userName, albumID)
+	highlightReel, err := c.Instagram.GetSingleHighlightAlbum(ctx, userName, albumID)
 	if err != nil {
-		escapedUser := formatter.EscapeMarkdownV2(userName)
-		errMsg := fmt.Sprintf("❌ Error fetching highlight album for @%s: %v", escapedUser, err)
-		if errors.Is(err, instagram.ErrPrivateAccount) {
-			errMsg = fmt.Sprintf("Account @%s is private, I cannot fetch highlights.", escapedUser)
+		c.Logger.Error("Error fetching highlight album", "user", userName, "albumID", albumID, "error", err)
+		errMsg := scrapeErrorMessage(err, "the highlight album of @"+escapedUser)
+		if errors.Is(err, instagram.ErrHighlightNotFound) {
+			errMsg = fmt.Sprintf("⌛ This album has changed or was removed from @%s. Please run /highlights %s again.", escapedUser, escapedUser)
 		}
 		c.Telegram.EditMessageText(chatID, messageID, errMsg)
 		return
@@ -389,96 +455,31 @@ func (c *CommandImpl) downloadSingleHighlightAlbum(ctx context.Context, chatID i
 
 // processBatch handles downloading and sending a batch of media items
 func (c *CommandImpl) processBatch(ctx context.Context, chatID int64, batchItems []domain.StoryItem, albumTitle string, isFirstBatch bool) bool {
-	var wg sync.WaitGroup
-	// Channel now stores paths to temp files instead of media data
-	tempFilePathsChannel := make(chan string, len(batchItems))
-
-	// Start downloading all items in this batch to temp files
-	for _, item := range batchItems {
-		wg.Add(1)
-		go func(mediaItem domain.StoryItem) {
-			defer wg.Done()
-
-			// Download media to temp file instead of memory
-			filePath, err := c.Telegram.DownloadMediaToTempFile(mediaItem.MediaURL)
-			if err != nil {
-				c.Logger.Error("Failed to download media to temp file", "url", mediaItem.MediaURL, "error", err)
-				return // Skip this file if download fails
-			}
-			tempFilePathsChannel <- filePath
-		}(item)
-	}
-
-	// Wait for all downloads to complete
-	wg.Wait()
-	close(tempFilePathsChannel)
-
-	// Collect temp file paths from channel
-	var tempFilePaths []string
-	for path := range tempFilePathsChannel {
-		tempFilePaths = append(tempFilePaths, path)
-	}
-
-	// IMPORTANT: Ensure temp files are always deleted
-	defer func() {
-		for _, path := range tempFilePaths {
-			if err := os.Remove(path); err != nil {
-				c.Logger.Warn("Failed to remove temp file", "path", path, "error", err)
-			}
-		}
-	}()
-
-	if len(tempFilePaths) == 0 {
-		c.Logger.Warn("Failed to download any media from batch", "batch_size", len(batchItems))
+	if ctx.Err() != nil {
 		return false
 	}
 
-	// Create media group from file paths
-	mediaGroup := make([]interface{}, 0, len(tempFilePaths))
-	for i, path := range tempFilePaths {
-		// Find the original item to determine if it's a video or photo
-		var isVideo bool
-		if i < len(batchItems) {
-			isVideo = strings.Contains(batchItems[i].MediaURL, ".mp4")
-		}
-
-		// Use FilePath instead of FileBytes
-		fileData := tgbotapi.FilePath(path)
-
-		// Create appropriate media type based on file type
-		if isVideo {
-			mediaGroup = append(mediaGroup, tgbotapi.NewInputMediaVideo(fileData))
-		} else {
-			mediaGroup = append(mediaGroup, tgbotapi.NewInputMediaPhoto(fileData))
-		}
+	media := make([]domain.MediaItem, 0, len(batchItems))
+	for _, item := range batchItems {
+		media = append(media, item.GetMedia())
 	}
 
-	// Set caption only for the first media item in the first batch
-	if isFirstBatch && len(mediaGroup) > 0 {
-		caption := fmt.Sprintf("Highlight: %s", albumTitle)
-		switch m := mediaGroup[0].(type) {
-		case tgbotapi.InputMediaVideo:
-			m.Caption = caption
-			mediaGroup[0] = m
-		case tgbotapi.InputMediaPhoto:
-			m.Caption = caption
-			mediaGroup[0] = m
-		}
+	caption := ""
+	if isFirstBatch {
+		caption = fmt.Sprintf("Highlight: %s", albumTitle)
 	}
 
-	// Send media group
-	if err := c.Telegram.SendMediaGroup(chatID, mediaGroup); err != nil {
+	if err := c.Telegram.SendMediaAlbum(chatID, media, caption); err != nil {
 		c.Logger.Error("Failed to send highlight media group batch", "title", albumTitle, "error", err)
 
 		// Fallback: try sending individually for this batch
-		caption := fmt.Sprintf("Highlight: %s", albumTitle)
-		if isFirstBatch {
+		if caption != "" {
 			c.Telegram.SendMessage(chatID, caption)
 		}
 
-		for _, item := range batchItems {
-			if err := c.Telegram.SendMediaByUrl(chatID, item.MediaURL); err != nil {
-				c.Logger.Error("Failed to send individual media", "url", item.MediaURL, "error", err)
+		for _, item := range media {
+			if _, err := c.Telegram.SendMedia(chatID, item, ""); err != nil {
+				c.Logger.Error("Failed to send individual media", "url", item.URL, "error", err)
 			}
 		}
 
@@ -14,13 +14,13 @@ import (
 func (c *CommandImpl) handleSubscribe(ctx context.Context, chatID int64, args string) {
 	parts := strings.Fields(args)
 	if len(parts) == 0 {
-		c.Telegram.SendMessage(chatID, "Please provide a username. Usage: /subscribe <username> [post|story|all]")
+		c.Telegram.SendMessage(chatID, "Please provide a username. Usage: /subscribe <username> [post|reel|story|highlight|profile|all]")
 		return
 	}
 
 	username := subscription.SanitizeUsername(parts[0])
 	if username == "" {
-		c.Telegram.SendMessage(chatID, "Please provide a valid username. Usage: /subscribe <username> [post|story|all]")
+		c.Telegram.SendMessage(chatID, "Please provide a valid username. Usage: /subscribe <username> [post|reel|story|highlight|profile|all]")
 		return
 	}
 
@@ -31,7 +31,7 @@ func (c *CommandImpl) handleSubscribe(ctx context.Context, chatID int64, args st
 		if domain.IsValidSubscriptionType(specifiedType) {
 			subscriptionType = specifiedType
 		} else {
-			c.Telegram.SendMessage(chatID, "Invalid subscription type. Valid types are: post, story, all. Using default: story.")
+			c.Telegram.SendMessage(chatID, "Invalid subscription type. Valid types are: post, reel, story, highlight, profile, all. Using default: story.")
 		}
 	}
 
@@ -65,8 +65,14 @@ func (c *CommandImpl) handleSubscribe(ctx context.Context, chatID int64, args st
 		contentType = "posts"
 	case domain.SubscriptionTypeStory:
 		contentType = "stories"
+	case domain.SubscriptionTypeReel:
+		contentType = "reels"
+	case domain.SubscriptionTypeHighlight:
+		contentType = "highlights"
+	case domain.SubscriptionTypeProfile:
+		contentType = "profile changes"
 	case domain.SubscriptionTypeAll:
-		contentType = "posts and stories"
+		contentType = "posts, reels and stories"
 	}
 
 	c.Telegram.SendMessage(chatID, fmt.Sprintf("✅ Successfully subscribed! You will now receive new %s from @%s.", contentType, escapedUsername))
@@ -119,7 +125,10 @@ func (c *CommandImpl) handleListSubscriptions(ctx context.Context, chatID int64)
 	builder.WriteString("\n*Available subscription types:*\n")
 	builder.WriteString("• story - receive only stories\n")
 	builder.WriteString("• post - receive only posts\n")
-	builder.WriteString("• all - receive both posts and stories\n\n")
+	builder.WriteString("• reel - receive only reels\n")
+	builder.WriteString("• highlight - receive newly added highlights\n")
+	builder.WriteString("• profile - receive bio, name, avatar and follower milestone changes\n")
+	builder.WriteString("• all - receive posts, reels and stories\n\n")
 	builder.WriteString("To change subscription type: /subscribe <username> <type>")
 
 	c.Telegram.SendMessage(chatID, builder.String())
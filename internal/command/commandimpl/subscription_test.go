@@ -0,0 +1,140 @@
+package commandimpl
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/subscription"
+	mock_subscription "github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/subscription/mocks"
+	mock_telegram "github.com/orgball2608/insta-parser-telegram-bot/internal/telegram/mocks"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/logger"
+	"go.uber.org/mock/gomock"
+)
+
+const testChatID int64 = 42
+
+func newSubscriptionTestCommand(t *testing.T) (*CommandImpl, *mock_subscription.MockRepository, *mock_telegram.MockClient) {
+	ctrl := gomock.NewController(t)
+	subs := mock_subscription.NewMockRepository(ctrl)
+	tg := mock_telegram.NewMockClient(ctrl)
+
+	c := &CommandImpl{
+		Telegram:         tg,
+		Logger:           logger.New(logger.Opts{Env: "test"}),
+		SubscriptionRepo: subs,
+	}
+	return c, subs, tg
+}
+
+// expectMessage expects one message to testChatID containing want.
+func expectMessage(tg *mock_telegram.MockClient, want string) {
+	tg.EXPECT().SendMessage(testChatID, gomock.Cond(func(text string) bool {
+		return strings.Contains(text, want)
+	})).Return(1, nil)
+}
+
+func TestHandleSubscribe(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     string
+		wantType string
+		warning  string
+		reply    string
+	}{
+		{name: "defaults to story", args: "someone", wantType: domain.SubscriptionTypeStory, reply: "new stories from @someone"},
+		{name: "post", args: "someone post", wantType: domain.SubscriptionTypePost, reply: "new posts from @someone"},
+		{name: "type is case insensitive", args: "someone REEL", wantType: domain.SubscriptionTypeReel, reply: "new reels from @someone"},
+		{name: "all", args: "@someone all", wantType: domain.SubscriptionTypeAll, reply: "new posts, reels and stories"},
+		{name: "invalid type falls back to story", args: "someone videos", wantType: domain.SubscriptionTypeStory, warning: "Invalid subscription type", reply: "new stories from @someone"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, subs, tg := newSubscriptionTestCommand(t)
+
+			if tt.warning != "" {
+				expectMessage(tg, tt.warning)
+			}
+			subs.EXPECT().Create(gomock.Any(), domain.Subscription{
+				ChatID:            testChatID,
+				InstagramUsername: "someone",
+				SubscriptionType:  tt.wantType,
+			}).Return(nil)
+			expectMessage(tg, tt.reply)
+
+			c.handleSubscribe(context.Background(), testChatID, tt.args)
+		})
+	}
+}
+
+func TestHandleSubscribeUpdatesExistingType(t *testing.T) {
+	c, subs, tg := newSubscriptionTestCommand(t)
+
+	subs.EXPECT().Create(gomock.Any(), gomock.Any()).Return(subscription.ErrAlreadyExists)
+	subs.EXPECT().UpdateSubscriptionType(gomock.Any(), testChatID, "someone", domain.SubscriptionTypeHighlight).Return(nil)
+	expectMessage(tg, "Updated subscription type to 'highlight'")
+
+	c.handleSubscribe(context.Background(), testChatID, "someone highlight")
+}
+
+func TestHandleSubscribeRequiresUsername(t *testing.T) {
+	c, _, tg := newSubscriptionTestCommand(t)
+
+	expectMessage(tg, "Please provide a username")
+
+	c.handleSubscribe(context.Background(), testChatID, "  ")
+}
+
+func TestHandleUnsubscribe(t *testing.T) {
+	tests := []struct {
+		name  string
+		err   error
+		reply string
+	}{
+		{name: "subscribed", reply: "Successfully unsubscribed from @someone"},
+		{name: "not subscribed", err: subscription.ErrNotFound, reply: "You are not subscribed to @someone"},
+		{name: "repository error", err: errors.New("connection refused"), reply: "An error occurred"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, subs, tg := newSubscriptionTestCommand(t)
+
+			subs.EXPECT().Delete(gomock.Any(), testChatID, "someone").Return(tt.err)
+			expectMessage(tg, tt.reply)
+
+			c.handleUnsubscribe(context.Background(), testChatID, "@someone")
+		})
+	}
+}
+
+func TestHandleListSubscriptions(t *testing.T) {
+	c, subs, tg := newSubscriptionTestCommand(t)
+
+	subs.EXPECT().GetByChatID(gomock.Any(), testChatID).Return([]*domain.Subscription{
+		{ChatID: testChatID, InstagramUsername: "first", SubscriptionType: domain.SubscriptionTypePost},
+		{ChatID: testChatID, InstagramUsername: "second", SubscriptionType: domain.SubscriptionTypeStory},
+	}, nil)
+	tg.EXPECT().SendMessage(testChatID, gomock.Any()).DoAndReturn(func(_ int64, text string) (int, error) {
+		for _, want := range []string{"1. @first (post)", "2. @second (story)"} {
+			if !strings.Contains(text, want) {
+				t.Errorf("list reply %q does not contain %q", text, want)
+			}
+		}
+		return 1, nil
+	})
+
+	c.handleListSubscriptions(context.Background(), testChatID)
+}
+
+func TestHandleListSubscriptionsEmpty(t *testing.T) {
+	c, subs, tg := newSubscriptionTestCommand(t)
+
+	subs.EXPECT().GetByChatID(gomock.Any(), testChatID).Return(nil, nil)
+	expectMessage(tg, "You are not subscribed to any accounts")
+
+	c.handleListSubscriptions(context.Background(), testChatID)
+}
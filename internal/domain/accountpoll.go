@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// AccountPoll is the polling state of one account for one content type. JobType is the
+// subscription type that is polled: SubscriptionTypeStory or SubscriptionTypePost.
+type AccountPoll struct {
+	Username     string
+	JobType      string
+	Interval     time.Duration // Interval picked from the account's recent activity
+	ItemsSeen    int           // Items the interval was computed from
+	LastPolledAt *time.Time    // Nil until the account is polled for the first time
+	NextPollAt   time.Time
+	UpdatedAt    time.Time
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// Delivery statuses
+const (
+	DeliveryStatusSent   = "sent"
+	DeliveryStatusFailed = "failed"
+)
+
+// Delivery records sending one piece of content (a story, post or reel) to one chat
+type Delivery struct {
+	ID          int
+	ContentType string // Subscription type of the content, e.g. SubscriptionTypeStory
+	ContentID   string // Story ID or post shortcode
+	ChatID      int64
+	Status      string // DeliveryStatusSent or DeliveryStatusFailed
+	Attempts    int
+	MessageID   int    // Telegram message ID of the first sent message, 0 when unknown
+	LastError   string // Error of the latest failed attempt
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
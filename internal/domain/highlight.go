@@ -6,14 +6,17 @@ type Highlights struct {
 	ID        int
 	UserName  string
 	MediaURL  string
+	AlbumID   string // Set for items tracked by highlight subscriptions
+	ItemID    string // Set for items tracked by highlight subscriptions
 	CreatedAt time.Time
 }
 
 // HighlightReel contains all stories in an album
 type HighlightReel struct {
-	ID    string // Album ID
-	Title string
-	Items []StoryItem
+	ID       string // Album ID
+	Title    string
+	Items    []StoryItem
+	Provider string // Name of the scraper provider that served the album
 }
 
 // HighlightAlbumPreview contains just enough information for user selection
@@ -21,4 +24,17 @@ type HighlightAlbumPreview struct {
 	ID       string
 	Title    string
 	CoverURL string
+	Provider string // Name of the scraper provider that served the preview
+}
+
+// HighlightAlbum is a highlight album that has been listed to a user. Its serial ID is what
+// inline buttons refer to, since Telegram limits callback data to 64 bytes.
+type HighlightAlbum struct {
+	ID         int
+	Username   string
+	AlbumID    string // Stable album ID reported by the scraper
+	Title      string
+	CoverURL   string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
 }
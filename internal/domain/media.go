@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// MediaResolution is one available rendition of a media item.
+type MediaResolution struct {
+	URL    string
+	Width  int // 0 when unknown
+	Height int // 0 when unknown
+}
+
+// MediaItem is a single photo or video of a post, story or highlight.
+type MediaItem struct {
+	Type         MediaType         // Photo (MediaTypeImage) or video (MediaTypeVideo)
+	URL          string            // URL of the best available rendition
+	Width        int               // Width of the best rendition, 0 when unknown
+	Height       int               // Height of the best rendition, 0 when unknown
+	Duration     time.Duration     // Video duration, 0 for photos or when unknown
+	ThumbnailURL string            // Preview image of a video, if known
+	Resolutions  []MediaResolution // Available renditions, best first
+}
+
+func (m MediaItem) IsVideo() bool {
+	return m.Type == MediaTypeVideo
+}
+
+// NewMediaItem builds a media item from its renditions, picking the largest one as URL.
+func NewMediaItem(mediaType MediaType, resolutions []MediaResolution) MediaItem {
+	var valid []MediaResolution
+	for _, r := range resolutions {
+		if r.URL != "" {
+			valid = append(valid, r)
+		}
+	}
+	sort.SliceStable(valid, func(i, j int) bool {
+		return valid[i].Width*valid[i].Height > valid[j].Width*valid[j].Height
+	})
+
+	item := MediaItem{Type: mediaType, Resolutions: valid}
+	if len(valid) > 0 {
+		item.URL = valid[0].URL
+		item.Width = valid[0].Width
+		item.Height = valid[0].Height
+	}
+	return item
+}
@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// Outbox message statuses
+const (
+	OutboxStatusPending = "pending"
+	OutboxStatusSent    = "sent"
+	OutboxStatusDead    = "dead" // Gave up after the maximum number of attempts
+)
+
+// OutboxMessage is a notification waiting to be sent to one chat. It is written in the same
+// transaction that records the content as seen, and sent later by the outbox dispatcher.
+type OutboxMessage struct {
+	ID            int
+	ContentType   string // Subscription type of the content, e.g. SubscriptionTypeStory
+	ContentID     string // Story ID, post or reel shortcode, first new highlight item ID, or profile snapshot ID
+	ChatID        int64
+	Payload       []byte // JSON encoded content to send, depending on ContentType
+	Status        string
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
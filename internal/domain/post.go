@@ -3,17 +3,28 @@ package domain
 import "time"
 
 type PostItem struct {
-	ID        string    // Post ID from Instagram
-	PostURL   string    // URL to the post
-	URL       string    // Alias for PostURL for compatibility
-	Username  string    // Instagram username
-	Caption   string    // Post caption
-	MediaURLs []string  // URLs of media (images/videos)
-	IsVideo   bool      // Whether the post is a video
-	TakenAt   time.Time // When the post was taken
-	Timestamp time.Time // When the post was parsed
-	LikeCount int       // Number of likes
-	PostedAgo string    // Human-readable time since posting
+	ID        string      // Post ID from Instagram
+	PostURL   string      // URL to the post
+	URL       string      // Alias for PostURL for compatibility
+	Username  string      // Instagram username
+	Caption   string      // Post caption
+	Media     []MediaItem // Photos and videos of the post, in carousel order
+	TakenAt   time.Time   // When the post was taken
+	Timestamp time.Time   // When the post was parsed
+	LikeCount int         // Number of likes
+	PostedAgo string      // Human-readable time since posting
+	IsReel    bool        // Whether the post is a reel, when known
+	Provider  string      // Name of the scraper provider that served the post
+}
+
+// HasVideo reports whether any media item of the post is a video.
+func (p *PostItem) HasVideo() bool {
+	for _, m := range p.Media {
+		if m.IsVideo() {
+			return true
+		}
+	}
+	return false
 }
 
 // For backward compatibility
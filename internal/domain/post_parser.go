@@ -2,11 +2,19 @@ package domain
 
 import "time"
 
+// Checkers that record parsed posts
+const (
+	PostSourcePost = "post"
+	PostSourceReel = "reel"
+)
+
 // PostParser represents a parsed Instagram post
 type PostParser struct {
 	ID        int
 	PostID    string
 	Username  string
 	PostURL   string
+	Source    string // Checker that first recorded the post, PostSourcePost when empty
+	Baseline  bool   // Recorded by the first check of the account, so never delivered
 	CreatedAt time.Time
 }
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"net/url"
+	"path"
+	"time"
+)
+
+// UserProfile is the public header of an Instagram account
+type UserProfile struct {
+	Username       string
+	FullName       string
+	Biography      string
+	AvatarURL      string // Highest resolution avatar available
+	FollowerCount  int
+	FollowingCount int
+	PostCount      int
+	IsVerified     bool
+	IsPrivate      bool
+	Provider       string // Name of the scraper provider that served the profile
+}
+
+// AvatarKey identifies the avatar image independently of the signed CDN parameters, which
+// change on every request. Proxied links are resolved to the CDN URL in their "uri" parameter.
+func (p UserProfile) AvatarKey() string {
+	if p.AvatarURL == "" {
+		return ""
+	}
+	parsed, err := url.Parse(p.AvatarURL)
+	if err != nil {
+		return p.AvatarURL
+	}
+	if nested, err := url.Parse(parsed.Query().Get("uri")); err == nil && nested.Path != "" {
+		parsed = nested
+	}
+	return path.Base(parsed.Path)
+}
+
+// ProfileSnapshot is a stored copy of an account's profile, used to detect changes
+type ProfileSnapshot struct {
+	ID int
+	UserProfile
+	Avatar    []byte // Avatar image at capture time; CDN links expire, so it is kept inline
+	CreatedAt time.Time
+}
+
+// AccountSnapshot is everything a single visit to an account's profile yields. Private
+// accounts only have Profile set.
+type AccountSnapshot struct {
+	Profile    UserProfile
+	Stories    []StoryItem
+	Posts      []PostItem              // Latest posts, newest first
+	Highlights []HighlightAlbumPreview // Nil when the provider cannot list highlight albums
+	Provider   string                  // Name of the scraper provider that served the snapshot
+}
@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+// Scrape job statuses. Finished jobs are deleted and jobs that ran out of attempts are moved
+// to the dead-letter table, so only these two are stored.
+const (
+	ScrapeJobStatusQueued  = "queued"
+	ScrapeJobStatusRunning = "running"
+)
+
+// ScrapeJobTypeSnapshot is the job type of a combined check of every content type of an
+// account from a single profile visit.
+const ScrapeJobTypeSnapshot = "snapshot"
+
+// ScrapeJob is a queued check of one account. JobType is the subscription type whose content
+// is checked: SubscriptionTypeStory, SubscriptionTypePost or SubscriptionTypeHighlight, or
+// ScrapeJobTypeSnapshot.
+type ScrapeJob struct {
+	ID        int
+	JobType   string
+	Username  string
+	Status    string
+	Attempts  int           // Failed attempts so far
+	Claim     int           // Number of the claim the job was handed out under
+	Timeout   time.Duration // Visibility timeout: a running job is handed out again once it expires
+	RunAt     time.Time     // When the job becomes due
+	LastError string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// DeadScrapeJob is a scrape job that failed on every attempt
+type DeadScrapeJob struct {
+	ID        int
+	JobType   string
+	Username  string
+	Attempts  int
+	Timeout   time.Duration
+	LastError string
+	CreatedAt time.Time // When the original job was queued
+	FailedAt  time.Time
+}
@@ -29,4 +29,15 @@ type StoryItem struct {
 	MediaType MediaType
 	TakenAt   time.Time
 	Username  string
+	Provider  string    // Name of the scraper provider that served the item
+	Media     MediaItem // Renditions and metadata of MediaURL
+}
+
+// GetMedia returns the item's media, built from MediaURL and MediaType when the provider
+// did not fill in Media.
+func (s StoryItem) GetMedia() MediaItem {
+	if s.Media.URL != "" {
+		return s.Media
+	}
+	return NewMediaItem(s.MediaType, []MediaResolution{{URL: s.MediaURL}})
 }
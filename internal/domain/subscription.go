@@ -4,9 +4,12 @@ import "time"
 
 // Subscription types
 const (
-	SubscriptionTypeStory = "story"
-	SubscriptionTypePost  = "post"
-	SubscriptionTypeAll   = "all"
+	SubscriptionTypeStory     = "story"
+	SubscriptionTypePost      = "post"
+	SubscriptionTypeReel      = "reel"
+	SubscriptionTypeProfile   = "profile"
+	SubscriptionTypeHighlight = "highlight"
+	SubscriptionTypeAll       = "all"
 )
 
 type Subscription struct {
@@ -21,5 +24,19 @@ type Subscription struct {
 func IsValidSubscriptionType(subType string) bool {
 	return subType == SubscriptionTypeStory ||
 		subType == SubscriptionTypePost ||
+		subType == SubscriptionTypeReel ||
+		subType == SubscriptionTypeProfile ||
+		subType == SubscriptionTypeHighlight ||
 		subType == SubscriptionTypeAll
 }
+
+// SubscriptionTypesFor returns the subscription types that receive content of subscriptionType.
+// "all" only covers stories and posts, which includes reels from the posts tab: reels,
+// highlights and profile changes became subscribable later, and existing "all" subscribers
+// never asked for the last two.
+func SubscriptionTypesFor(subscriptionType string) []string {
+	if subscriptionType == SubscriptionTypeStory || subscriptionType == SubscriptionTypePost {
+		return []string{subscriptionType, SubscriptionTypeAll}
+	}
+	return []string{subscriptionType}
+}
@@ -2,18 +2,21 @@ package api_adapter
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"math/rand"
 	"net/url"
+	"os"
 	"path"
-	"runtime/debug"
-	"strconv"
+	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram/scraperprofile"
 	"github.com/orgball2608/insta-parser-telegram-bot/pkg/config"
 	"github.com/orgball2608/insta-parser-telegram-bot/pkg/logger"
 	"github.com/orgball2608/insta-parser-telegram-bot/pkg/retry"
@@ -21,75 +24,22 @@ import (
 	"go.uber.org/fx"
 )
 
-type PlaywrightManager struct {
-	pw      *playwright.Playwright
-	browser playwright.Browser
-	logger  logger.Logger
-}
-
-func (pm *PlaywrightManager) Browser() playwright.Browser {
-	return pm.browser
-}
-
-func NewPlaywrightManager(lc fx.Lifecycle, log logger.Logger) (*PlaywrightManager, error) {
-	log.Info("Initializing Playwright Manager...")
-	pw, err := playwright.Run()
-	if err != nil {
-		return nil, fmt.Errorf("could not start playwright: %w", err)
-	}
-
-	browser, err := pw.Chromium.Launch(playwright.BrowserTypeLaunchOptions{
-		Headless: playwright.Bool(true),
-		Args: []string{
-			"--no-sandbox",
-			"--disable-setuid-sandbox",
-			"--disable-dev-shm-usage",
-			"--disable-accelerated-2d-canvas",
-			"--no-first-run",
-			"--no-zygote",
-			"--disable-gpu",
-		},
-	})
-	if err != nil {
-		_ = pw.Stop()
-		return nil, fmt.Errorf("could not launch browser: %w", err)
-	}
-
-	manager := &PlaywrightManager{
-		pw:      pw,
-		browser: browser,
-		logger:  log,
-	}
-
-	lc.Append(fx.Hook{
-		OnStop: func(ctx context.Context) error {
-			log.Info("Shutting down Playwright browser...")
-			if err := manager.browser.Close(); err != nil {
-				log.Error("Failed to close playwright browser", "error", err)
-			}
-			if err := manager.pw.Stop(); err != nil {
-				log.Error("Failed to stop playwright", "error", err)
-				return err
-			}
-			log.Info("Playwright stopped successfully.")
-			return nil
-		},
-	})
-	log.Info("Playwright Manager initialized successfully.")
-	return manager, nil
-}
-
 type Opts struct {
 	fx.In
 	Config     *config.Config
 	Logger     logger.Logger
 	Playwright *PlaywrightManager
+	Profiles   *scraperprofile.Store
 }
 
+// ProviderName is the name under which the Playwright scraper is registered.
+const ProviderName = "playwright"
+
 type APIAdapter struct {
 	config     *config.Config
 	logger     logger.Logger
 	playwright *PlaywrightManager
+	profiles   *scraperprofile.Store
 }
 
 func New(opts Opts) instagram.Client {
@@ -97,42 +47,164 @@ func New(opts Opts) instagram.Client {
 		config:     opts.Config,
 		logger:     opts.Logger,
 		playwright: opts.Playwright,
+		profiles:   opts.Profiles,
 	}
 }
 
-func (a *APIAdapter) newScrapingPage(ctx context.Context, url string) (playwright.Page, func(), error) {
-	brContext, err := a.playwright.Browser().NewContext(playwright.BrowserNewContextOptions{
-		UserAgent: playwright.String("Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.0.0 Safari/537.36"),
-	})
-	if err != nil {
-		return nil, nil, fmt.Errorf("could not create browser context: %w", err)
+// NewProvider registers the Playwright scraper in the instagram provider registry.
+func NewProvider(opts Opts) instagram.Provider {
+	return instagram.Provider{
+		Name:   ProviderName,
+		Client: New(opts),
 	}
+}
 
-	cleanup := func() {
-		brContext.Close()
-		debug.FreeOSMemory()
+func (a *APIAdapter) newScrapingPage(ctx context.Context, p *scraperprofile.Profile, url string) (playwright.Page, func(), error) {
+	page, release, err := a.playwright.NewPage(ctx)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	page, err := brContext.NewPage()
-	if err != nil {
-		cleanup()
-		return nil, nil, fmt.Errorf("could not create new page: %w", err)
+	// Closing the page aborts whatever Playwright call is in flight once ctx is cancelled.
+	stop := context.AfterFunc(ctx, func() {
+		if err := page.Close(); err != nil {
+			a.logger.Debug("Failed to close page after cancellation", "error", err)
+		}
+	})
+	cleanup := func() {
+		stop()
+		release()
 	}
 
 	gotoOperation := func() error {
-		_, err := page.Goto(url, playwright.PageGotoOptions{Timeout: playwright.Float(60000)})
-		return err
+		_, err := page.Goto(url, playwright.PageGotoOptions{Timeout: playwright.Float(p.Timeouts.NavigationMs)})
+		return retryableBrowserError(err)
 	}
 
 	err = retry.Do(ctx, a.logger, "PageGoto", gotoOperation, retry.DefaultConfig())
 	if err != nil {
+		defer cleanup()
+		return nil, nil, newStepError(ctx, page, instagram.StepNavigation, fmt.Errorf("could not goto page '%s' after retries: %w", url, err))
+	}
+
+	return page, cleanup, nil
+}
+
+// openProfile searches for userName on the scraper's profile page and waits until either
+// the profile block or the site's error message is shown.
+func (a *APIAdapter) openProfile(ctx context.Context, p *scraperprofile.Profile, userName, contentKind string) (playwright.Page, func(), error) {
+	page, cleanup, err := a.newScrapingPage(ctx, p, p.URLs.Profile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := a.searchProfile(ctx, page, p, userName, contentKind); err != nil {
 		cleanup()
-		return nil, nil, fmt.Errorf("could not goto page '%s' after retries: %w", url, err)
+		return nil, nil, err
 	}
 
 	return page, cleanup, nil
 }
 
+func (a *APIAdapter) searchProfile(ctx context.Context, page playwright.Page, p *scraperprofile.Profile, userName, contentKind string) error {
+	if err := page.Type(p.Selectors.SearchInput, userName, playwright.PageTypeOptions{Timeout: playwright.Float(p.Timeouts.TypeMs)}); err != nil {
+		return newStepError(ctx, page, instagram.StepSearchForm, fmt.Errorf("could not type username: %w", err))
+	}
+	if err := sleepCtx(ctx, time.Duration(500+rand.Intn(1000))*time.Millisecond); err != nil {
+		return err
+	}
+
+	clickOperation := func() error {
+		return retryableBrowserError(page.Click(p.Selectors.SearchButton))
+	}
+	if err := retry.Do(ctx, a.logger, "SearchButtonClick", clickOperation, retry.DefaultConfig()); err != nil {
+		return newStepError(ctx, page, instagram.StepSearchForm, fmt.Errorf("could not click search button after retries: %w", err))
+	}
+
+	combinedSelector := p.Selectors.ProfileResult + ", " + p.Selectors.ErrorMessage
+	if _, err := page.WaitForSelector(combinedSelector, playwright.PageWaitForSelectorOptions{Timeout: playwright.Float(p.Timeouts.SearchResultsMs)}); err != nil {
+		return newStepError(ctx, page, instagram.StepSearchResults, fmt.Errorf("search results or error message did not load in time: %w", err))
+	}
+
+	if isPrivate, _ := page.IsVisible(p.Selectors.ErrorMessage); isPrivate {
+		a.logger.Warn("Account is private, cannot scrape "+contentKind, "user", userName)
+		return instagram.ErrPrivateAccount
+	}
+
+	return nil
+}
+
+// openTab switches the profile block to the given tab.
+func (a *APIAdapter) openTab(ctx context.Context, page playwright.Page, tabSelector, tabName, step string) error {
+	a.logger.Info(fmt.Sprintf("Processing '%s' tab...", tabName))
+	if err := page.Click(tabSelector); err != nil {
+		return newStepError(ctx, page, step, fmt.Errorf("could not click %s tab: %w", tabName, err))
+	}
+	return nil
+}
+
+// newStepError tags err with the scrape step that failed and saves a screenshot of the page.
+// Only the latest screenshot per step is kept, so the directory does not grow unbounded.
+// A cancelled scrape is reported as the context error instead, since its page is already closed.
+// Unless err is already classified, the step decides its kind: a failed navigation means the
+// site is down, a result that never loads is a timeout and anything else is a missing selector.
+func newStepError(ctx context.Context, page playwright.Page, step string, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+
+	screenshotPath := filepath.Join("tmp", "screenshots", strings.ReplaceAll(step, " ", "_")+".png")
+	if mkErr := os.MkdirAll(filepath.Dir(screenshotPath), os.ModePerm); mkErr != nil {
+		screenshotPath = ""
+	} else if _, shotErr := page.Screenshot(playwright.PageScreenshotOptions{
+		Path:     playwright.String(screenshotPath),
+		FullPage: playwright.Bool(true),
+	}); shotErr != nil {
+		screenshotPath = ""
+	}
+
+	if !errors.Is(err, instagram.ErrEmptyResult) {
+		err = fmt.Errorf("%w: %w", stepErrorKind(step), err)
+	}
+
+	return &instagram.StepError{Step: step, Screenshot: screenshotPath, Err: err}
+}
+
+// retryableBrowserError tags a failed browser action so that retry.Do tries it again.
+func retryableBrowserError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, playwright.ErrTimeout) {
+		return fmt.Errorf("%w: %w", instagram.ErrTimeout, err)
+	}
+	return fmt.Errorf("%w: %w", instagram.ErrProviderDown, err)
+}
+
+func stepErrorKind(step string) error {
+	switch step {
+	case instagram.StepNavigation:
+		return instagram.ErrProviderDown
+	case instagram.StepSearchResults, instagram.StepMediaResult:
+		return instagram.ErrTimeout
+	default:
+		return instagram.ErrSelectorMissing
+	}
+}
+
+// sleepCtx pauses for d, returning early with the context error if ctx is cancelled.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 // // setupRequestInterception block unnecessary resources
 // func setupRequestInterception(ctx playwright.BrowserContext) error {
 // 	return ctx.Route("**/*", func(route playwright.Route) {
@@ -145,159 +217,115 @@ func (a *APIAdapter) newScrapingPage(ctx context.Context, url string) (playwrigh
 // 	})
 // }
 
-func (a *APIAdapter) GetUserStories(userName string) ([]domain.StoryItem, error) {
-	return a.scrapeStoryLinks(userName)
+func (a *APIAdapter) GetUserStories(ctx context.Context, userName string) ([]domain.StoryItem, error) {
+	return a.scrapeStoryLinks(ctx, userName)
 }
 
-func (a *APIAdapter) GetUserHighlights(userName string, processorFunc instagram.HighlightReelProcessorFunc) error {
-	return a.scrapeHighlightLinks(userName, processorFunc)
+func (a *APIAdapter) GetUserHighlights(ctx context.Context, userName string, processorFunc instagram.HighlightReelProcessorFunc) error {
+	return a.scrapeHighlightLinks(ctx, userName, processorFunc)
 }
 
-func (a *APIAdapter) GetHighlightAlbumPreviews(userName string) ([]domain.HighlightAlbumPreview, error) {
+func (a *APIAdapter) GetHighlightAlbumPreviews(ctx context.Context, userName string) ([]domain.HighlightAlbumPreview, error) {
 	a.logger.Info("Scraping highlight album previews", "user", userName)
-	page, cleanup, err := a.newScrapingPage(context.Background(), "https://instasupersave.com/en/instagram-stories/")
+	p := a.profiles.Current()
+	page, cleanup, err := a.openProfile(ctx, p, userName, "highlights")
 	if err != nil {
 		return nil, err
 	}
 	defer cleanup()
 
-	if err = page.Type("#search-form-input", userName, playwright.PageTypeOptions{Timeout: playwright.Float(10000)}); err != nil {
-		return nil, fmt.Errorf("could not type username: %w", err)
-	}
-	time.Sleep(time.Duration(500+rand.Intn(1000)) * time.Millisecond)
-
-	clickOperation := func() error {
-		return page.Click("button.search-form__button")
-	}
-	err = retry.Do(context.Background(), a.logger, "SearchButtonClick", clickOperation, retry.DefaultConfig())
-	if err != nil {
-		return nil, fmt.Errorf("could not click search button after retries: %w", err)
-	}
-
-	combinedSelector := ".output-profile, .error-message"
-	if _, err = page.WaitForSelector(combinedSelector, playwright.PageWaitForSelectorOptions{Timeout: playwright.Float(90000)}); err != nil {
-		return nil, fmt.Errorf("search results or error message did not load in time: %w", err)
-	}
-
-	isPrivate, _ := page.IsVisible(".error-message")
-	if isPrivate {
-		a.logger.Warn("Account is private, cannot scrape highlights", "user", userName)
-		return nil, instagram.ErrPrivateAccount
-	}
+	return a.readHighlightPreviews(ctx, page, p, userName)
+}
 
-	a.logger.Info("Processing 'highlights' tab...")
-	tabSelector := "//button[contains(text(),'highlights')]"
-	if err := page.Click(tabSelector); err != nil {
-		return nil, fmt.Errorf("could not click highlights tab: %w", err)
+// readHighlightPreviews switches an opened profile to the highlights tab and lists its albums.
+func (a *APIAdapter) readHighlightPreviews(ctx context.Context, page playwright.Page, p *scraperprofile.Profile, userName string) ([]domain.HighlightAlbumPreview, error) {
+	if err := a.openTab(ctx, page, p.Tabs.Highlights, "highlights", instagram.StepHighlightsTab); err != nil {
+		return nil, err
 	}
 
-	highlightAlbumSelector := "button.highlight__button"
-	if _, err = page.WaitForSelector(highlightAlbumSelector, playwright.PageWaitForSelectorOptions{Timeout: playwright.Float(15000)}); err != nil {
+	highlightAlbumSelector := p.Selectors.HighlightAlbum
+	if _, err := page.WaitForSelector(highlightAlbumSelector, playwright.PageWaitForSelectorOptions{Timeout: playwright.Float(p.Timeouts.AlbumListMs)}); err != nil {
 		a.logger.Warn("No highlight albums found for user", "user", userName)
 		return []domain.HighlightAlbumPreview{}, nil
 	}
 
 	albumLocators, err := page.Locator(highlightAlbumSelector).All()
 	if err != nil {
-		return nil, fmt.Errorf("could not get highlight albums: %w", err)
+		return nil, fmt.Errorf("could not get highlight albums: %w: %w", instagram.ErrSelectorMissing, err)
 	}
 
-	var previews []domain.HighlightAlbumPreview
-	for i, locator := range albumLocators {
-		title, err := locator.Locator("p.highlight__title").InnerText()
-		if err != nil {
-			a.logger.Warn("Could not get title for album, using default", "index", i)
-			title = fmt.Sprintf("Highlight #%d", i+1)
-		}
+	albums, ids, err := a.readHighlightAlbums(albumLocators, p)
+	if err != nil {
+		return nil, fmt.Errorf("highlights of %s: %w", userName, err)
+	}
 
-		coverURL, err := locator.Locator("img.highlight__image").GetAttribute("src")
-		if err != nil {
-			a.logger.Warn("Could not get cover URL for album", "title", title)
-			coverURL = ""
+	previews := make([]domain.HighlightAlbumPreview, 0, len(albums))
+	for i, album := range albums {
+		title := album.Title
+		if title == "" {
+			title = fmt.Sprintf("Highlight #%d", i+1)
 		}
 
 		previews = append(previews, domain.HighlightAlbumPreview{
-			ID:       strconv.Itoa(i), // Use index as ID instead of title
+			ID:       ids[i],
 			Title:    title,
-			CoverURL: coverURL,
+			CoverURL: album.CoverURL,
 		})
 	}
 
 	return previews, nil
 }
 
-func (a *APIAdapter) GetSingleHighlightAlbum(userName, albumID string) (*domain.HighlightReel, error) {
+func (a *APIAdapter) GetSingleHighlightAlbum(ctx context.Context, userName, albumID string) (*domain.HighlightReel, error) {
 	a.logger.Info("Scraping single highlight album", "user", userName, "albumID", albumID)
 
-	// Convert albumID from string to integer index
-	albumIndex, err := strconv.Atoi(albumID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid albumID, expected an index: %s", albumID)
-	}
-
-	page, cleanup, err := a.newScrapingPage(context.Background(), "https://instasupersave.com/en/instagram-stories/")
+	p := a.profiles.Current()
+	page, cleanup, err := a.openProfile(ctx, p, userName, "highlights")
 	if err != nil {
 		return nil, err
 	}
 	defer cleanup()
 
-	if err = page.Type("#search-form-input", userName, playwright.PageTypeOptions{Timeout: playwright.Float(10000)}); err != nil {
-		return nil, fmt.Errorf("could not type username: %w", err)
-	}
-	time.Sleep(time.Duration(500+rand.Intn(1000)) * time.Millisecond)
-
-	clickOperation := func() error {
-		return page.Click("button.search-form__button")
-	}
-	err = retry.Do(context.Background(), a.logger, "SearchButtonClick", clickOperation, retry.DefaultConfig())
-	if err != nil {
-		return nil, fmt.Errorf("could not click search button after retries: %w", err)
-	}
-
-	combinedSelector := ".output-profile, .error-message"
-	if _, err = page.WaitForSelector(combinedSelector, playwright.PageWaitForSelectorOptions{Timeout: playwright.Float(90000)}); err != nil {
-		return nil, fmt.Errorf("search results or error message did not load in time: %w", err)
-	}
-
-	isPrivate, _ := page.IsVisible(".error-message")
-	if isPrivate {
-		a.logger.Warn("Account is private, cannot scrape highlights", "user", userName)
-		return nil, instagram.ErrPrivateAccount
-	}
-
-	a.logger.Info("Processing 'highlights' tab...")
-	tabSelector := "//button[contains(text(),'highlights')]"
-	if err := page.Click(tabSelector); err != nil {
-		return nil, fmt.Errorf("could not click highlights tab: %w", err)
+	if err := a.openTab(ctx, page, p.Tabs.Highlights, "highlights", instagram.StepHighlightsTab); err != nil {
+		return nil, err
 	}
 
 	// Get all highlight album buttons
-	albumSelector := "button.highlight__button"
-	if _, err = page.WaitForSelector(albumSelector, playwright.PageWaitForSelectorOptions{Timeout: playwright.Float(15000)}); err != nil {
-		return nil, fmt.Errorf("no highlight albums found on page: %w", err)
+	albumSelector := p.Selectors.HighlightAlbum
+	if _, err = page.WaitForSelector(albumSelector, playwright.PageWaitForSelectorOptions{Timeout: playwright.Float(p.Timeouts.AlbumListMs)}); err != nil {
+		return nil, newStepError(ctx, page, instagram.StepHighlightAlbums, fmt.Errorf("no highlight albums found on page: %w", err))
 	}
 
 	albumLocators, err := page.Locator(albumSelector).All()
 	if err != nil {
-		return nil, fmt.Errorf("could not get highlight album locators: %w", err)
+		return nil, fmt.Errorf("could not get highlight album locators: %w: %w", instagram.ErrSelectorMissing, err)
 	}
 
-	// Check if the index is valid
-	if albumIndex >= len(albumLocators) {
-		return nil, fmt.Errorf("album index %d out of bounds, only %d albums available", albumIndex, len(albumLocators))
+	albums, ids, err := a.readHighlightAlbums(albumLocators, p)
+	if err != nil {
+		return nil, fmt.Errorf("highlights of %s: %w", userName, err)
 	}
 
-	// Get the target album locator by index
-	targetAlbum := albumLocators[albumIndex]
-	title, _ := targetAlbum.Locator("p.highlight__title").InnerText()
+	// Find the album whose title and cover still hash to the requested ID
+	var targetAlbum playwright.Locator
+	var title string
+	for i, id := range ids {
+		if id == albumID {
+			targetAlbum, title = albumLocators[i], albums[i].Title
+			break
+		}
+	}
+	if targetAlbum == nil {
+		return nil, fmt.Errorf("album %s of %s: %w", albumID, userName, instagram.ErrHighlightNotFound)
+	}
 
 	// Click on the target album
-	if err := targetAlbum.Click(playwright.LocatorClickOptions{Timeout: playwright.Float(5000)}); err != nil {
-		return nil, fmt.Errorf("could not click on album index %d: %w", albumIndex, err)
+	if err := targetAlbum.Click(playwright.LocatorClickOptions{Timeout: playwright.Float(p.Timeouts.ClickMs)}); err != nil {
+		return nil, fmt.Errorf("could not click on album %q: %w: %w", title, instagram.ErrSelectorMissing, err)
 	}
 
 	// Extract all items
-	items, err := scrollAndExtractAllItems(page, userName)
+	items, err := scrollAndExtractAllItems(ctx, page, p, userName)
 	if err != nil {
 		return nil, err
 	}
@@ -309,117 +337,132 @@ func (a *APIAdapter) GetSingleHighlightAlbum(userName, albumID string) (*domain.
 	}, nil
 }
 
-func (a *APIAdapter) scrapeStoryLinks(userName string) ([]domain.StoryItem, error) {
-	a.logger.Info("Scraping stories", "user", userName)
-	page, cleanup, err := a.newScrapingPage(context.Background(), "https://instasupersave.com/en/instagram-stories/")
-	if err != nil {
-		return nil, err
-	}
-	defer cleanup()
-
-	if err = page.Type("#search-form-input", userName, playwright.PageTypeOptions{Timeout: playwright.Float(10000)}); err != nil {
-		return nil, fmt.Errorf("could not type username: %w", err)
+// readHighlightAlbums reads the title and cover of every listed highlight album and derives
+// their IDs, failing when two albums cannot be told apart.
+func (a *APIAdapter) readHighlightAlbums(locators []playwright.Locator, p *scraperprofile.Profile) ([]instagram.HighlightAlbumCover, []string, error) {
+	albums := make([]instagram.HighlightAlbumCover, 0, len(locators))
+	for _, locator := range locators {
+		title, coverURL := a.readHighlightAlbum(locator, p)
+		albums = append(albums, instagram.HighlightAlbumCover{Title: title, CoverURL: coverURL})
 	}
 
-	time.Sleep(time.Duration(500+rand.Intn(1000)) * time.Millisecond)
-
-	clickOperation := func() error {
-		return page.Click("button.search-form__button")
-	}
-	err = retry.Do(context.Background(), a.logger, "SearchButtonClick", clickOperation, retry.DefaultConfig())
+	ids, err := instagram.HighlightAlbumIDs(albums)
 	if err != nil {
-		return nil, fmt.Errorf("could not click search button after retries: %w", err)
-	}
-
-	combinedSelector := ".output-profile, .error-message"
-	if _, err = page.WaitForSelector(combinedSelector, playwright.PageWaitForSelectorOptions{Timeout: playwright.Float(90000)}); err != nil {
-		return nil, fmt.Errorf("search results or error message did not load in time: %w", err)
+		return nil, nil, err
 	}
+	return albums, ids, nil
+}
 
-	isPrivate, _ := page.IsVisible(".error-message")
-	if isPrivate {
-		a.logger.Warn("Account is private, cannot scrape stories", "user", userName)
-		return nil, instagram.ErrPrivateAccount
+// readHighlightAlbum returns the title and cover URL of a highlight album button; either is
+// empty when it cannot be read.
+func (a *APIAdapter) readHighlightAlbum(locator playwright.Locator, p *scraperprofile.Profile) (title, coverURL string) {
+	title, err := locator.Locator(p.Selectors.HighlightTitle).InnerText()
+	if err != nil {
+		a.logger.Warn("Could not get title for highlight album", "error", err)
 	}
 
-	a.logger.Info("Processing 'stories' tab...")
-	tabSelector := "//button[contains(text(),'stories')]"
-	if err := page.Click(tabSelector); err != nil {
-		return nil, fmt.Errorf("could not click stories tab: %w", err)
+	coverURL, err = locator.Locator(p.Selectors.HighlightCover).GetAttribute("src")
+	if err != nil {
+		a.logger.Warn("Could not get cover URL for highlight album", "title", title, "error", err)
 	}
-	time.Sleep(2 * time.Second)
 
-	return scrollAndExtractAllItems(page, userName)
+	return strings.TrimSpace(title), coverURL
 }
 
-func (a *APIAdapter) scrapeHighlightLinks(userName string, processorFunc instagram.HighlightReelProcessorFunc) error {
-	a.logger.Info("Scraping highlights", "user", userName)
-	page, cleanup, err := a.newScrapingPage(context.Background(), "https://instasupersave.com/en/instagram-stories/")
+func (a *APIAdapter) scrapeStoryLinks(ctx context.Context, userName string) ([]domain.StoryItem, error) {
+	a.logger.Info("Scraping stories", "user", userName)
+	p := a.profiles.Current()
+	page, cleanup, err := a.openProfile(ctx, p, userName, "stories")
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer cleanup()
 
-	if err = page.Type("#search-form-input", userName, playwright.PageTypeOptions{Timeout: playwright.Float(10000)}); err != nil {
-		return fmt.Errorf("could not type username: %w", err)
-	}
-
-	time.Sleep(time.Duration(500+rand.Intn(1000)) * time.Millisecond)
+	return a.readStories(ctx, page, p, userName)
+}
 
-	clickOperation := func() error {
-		return page.Click("button.search-form__button")
+// readStories switches an opened profile to the stories tab and extracts its items. An account
+// without live stories has no media list, which is not an error; only a missing tab is.
+func (a *APIAdapter) readStories(ctx context.Context, page playwright.Page, p *scraperprofile.Profile, userName string) ([]domain.StoryItem, error) {
+	if err := a.openTab(ctx, page, p.Tabs.Stories, "stories", instagram.StepStoriesTab); err != nil {
+		return nil, err
 	}
-	err = retry.Do(context.Background(), a.logger, "SearchButtonClick", clickOperation, retry.DefaultConfig())
-	if err != nil {
-		return fmt.Errorf("could not click search button after retries: %w", err)
+	if err := sleepCtx(ctx, time.Duration(p.Timeouts.TabSettleMs)*time.Millisecond); err != nil {
+		return nil, err
+	}
+
+	if _, err := page.WaitForSelector(p.Selectors.MediaList, playwright.PageWaitForSelectorOptions{Timeout: playwright.Float(p.Timeouts.MediaListMs)}); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		a.logger.Info("No stories found for user", "user", userName)
+		return []domain.StoryItem{}, nil
 	}
 
-	combinedSelector := ".output-profile, .error-message"
-	if _, err = page.WaitForSelector(combinedSelector, playwright.PageWaitForSelectorOptions{Timeout: playwright.Float(90000)}); err != nil {
-		return fmt.Errorf("search results or error message did not load in time: %w", err)
+	stories, err := scrollAndExtractAllItems(ctx, page, p, userName)
+	if errors.Is(err, instagram.ErrEmptyResult) {
+		a.logger.Info("Stories list is empty for user", "user", userName)
+		return []domain.StoryItem{}, nil
 	}
+	return stories, err
+}
 
-	isPrivate, _ := page.IsVisible(".error-message")
-	if isPrivate {
-		a.logger.Warn("Account is private, cannot scrape highlights", "user", userName)
-		return instagram.ErrPrivateAccount
+func (a *APIAdapter) scrapeHighlightLinks(ctx context.Context, userName string, processorFunc instagram.HighlightReelProcessorFunc) error {
+	a.logger.Info("Scraping highlights", "user", userName)
+	p := a.profiles.Current()
+	page, cleanup, err := a.openProfile(ctx, p, userName, "highlights")
+	if err != nil {
+		return err
 	}
+	defer cleanup()
 
-	a.logger.Info("Processing 'highlights' tab...")
-	tabSelector := "//button[contains(text(),'highlights')]"
-	if err := page.Click(tabSelector); err != nil {
-		return fmt.Errorf("could not click highlights tab: %w", err)
+	if err := a.openTab(ctx, page, p.Tabs.Highlights, "highlights", instagram.StepHighlightsTab); err != nil {
+		return err
 	}
 
-	highlightAlbumSelector := "button.highlight__button"
-	if _, err = page.WaitForSelector(highlightAlbumSelector, playwright.PageWaitForSelectorOptions{Timeout: playwright.Float(15000)}); err != nil {
+	highlightAlbumSelector := p.Selectors.HighlightAlbum
+	if _, err = page.WaitForSelector(highlightAlbumSelector, playwright.PageWaitForSelectorOptions{Timeout: playwright.Float(p.Timeouts.AlbumListMs)}); err != nil {
 		a.logger.Warn("Highlight albums did not appear.", "error", err)
 		return nil
 	}
 
-	albumCount, err := page.Locator(highlightAlbumSelector).Count()
+	albumLocators, err := page.Locator(highlightAlbumSelector).All()
+	if err != nil {
+		return fmt.Errorf("could not get highlight albums: %w: %w", instagram.ErrSelectorMissing, err)
+	}
+	a.logger.Info("Found highlight albums.", "count", len(albumLocators))
+
+	// Every album is identified before any is walked, so a collision doesn't mix up their items
+	albums, ids, err := a.readHighlightAlbums(albumLocators, p)
 	if err != nil {
-		return fmt.Errorf("could not count highlight albums: %w", err)
+		return fmt.Errorf("highlights of %s: %w", userName, err)
 	}
-	a.logger.Info("Found highlight albums.", "count", albumCount)
 
-	for i := 0; i < albumCount; i++ {
+	for i, album := range albums {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		currentAlbum := page.Locator(highlightAlbumSelector).Nth(i)
-		albumTitle, _ := currentAlbum.Locator("p.highlight__title").InnerText()
+		albumTitle := album.Title
 		a.logger.Info("Processing album", "index", i+1, "title", albumTitle)
 
-		if err := currentAlbum.Click(playwright.LocatorClickOptions{Timeout: playwright.Float(5000)}); err != nil {
+		if err := currentAlbum.Click(playwright.LocatorClickOptions{Timeout: playwright.Float(p.Timeouts.ClickMs)}); err != nil {
 			a.logger.Warn("Could not click on album, skipping.", "title", albumTitle, "error", err)
 			continue
 		}
 
-		highlightItems, err := scrollAndExtractAllItems(page, userName)
+		highlightItems, err := scrollAndExtractAllItems(ctx, page, p, userName)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
 		if err != nil {
 			a.logger.Error("Failed to extract items for album", "title", albumTitle, "error", err)
 			continue
 		}
 
 		reel := domain.HighlightReel{
+			ID:    ids[i],
 			Title: albumTitle,
 			Items: highlightItems,
 		}
@@ -434,21 +477,30 @@ func (a *APIAdapter) scrapeHighlightLinks(userName string, processorFunc instagr
 	return nil
 }
 
-func scrollAndExtractAllItems(page playwright.Page, userName string) ([]domain.StoryItem, error) {
+func scrollAndExtractAllItems(ctx context.Context, page playwright.Page, p *scraperprofile.Profile, userName string) ([]domain.StoryItem, error) {
 	itemsSet := make(map[string]domain.StoryItem)
+	var order []string
 	previousItemCount := -1
+	now := time.Now()
+
+	var mediaListErr error
+	for i := 0; i < p.Scroll.MaxAttempts; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 
-	for i := 0; i < 30; i++ {
-		mediaListSelector := "ul.profile-media-list"
-		if _, err := page.WaitForSelector(mediaListSelector, playwright.PageWaitForSelectorOptions{Timeout: playwright.Float(10000)}); err != nil {
+		mediaListSelector := p.Selectors.MediaList
+		if _, err := page.WaitForSelector(mediaListSelector, playwright.PageWaitForSelectorOptions{Timeout: playwright.Float(p.Timeouts.MediaListMs)}); err != nil {
 			if i == 0 {
 				log.Println("Media list container not found on first attempt, maybe no media.")
+				mediaListErr = err
 			}
 			break
 		}
 
-		downloadButtonSelector := "a.button__download"
+		downloadButtonSelector := p.Selectors.DownloadButton
 		locators, err := page.Locator(downloadButtonSelector).All()
+
 		if err != nil {
 			log.Printf("could not get download button locators: %v", err)
 			continue
@@ -470,18 +522,17 @@ func scrollAndExtractAllItems(page playwright.Page, userName string) ([]domain.S
 				continue
 			}
 
-			mediaType := domain.MediaTypeImage
-			if strings.Contains(href, ".mp4") {
-				mediaType = domain.MediaTypeVideo
-			}
+			mediaType := mediaTypeFromURL(href)
 
 			itemsSet[storyID] = domain.StoryItem{
 				ID:        storyID,
 				MediaURL:  href,
 				MediaType: mediaType,
+				Media:     domain.NewMediaItem(mediaType, []domain.MediaResolution{{URL: href}}),
 				Username:  userName,
-				TakenAt:   time.Now(),
+				TakenAt:   resolveTakenAt(locator, p.Selectors.MediaItem, p.Selectors.MediaTime, href, now),
 			}
+			order = append(order, storyID)
 		}
 
 		currentItemCount := len(itemsSet)
@@ -494,21 +545,54 @@ func scrollAndExtractAllItems(page playwright.Page, userName string) ([]domain.S
 		previousItemCount = currentItemCount
 
 		page.Evaluate("window.scrollTo(0, document.body.scrollHeight)")
-		time.Sleep(time.Duration(1500+rand.Intn(1000)) * time.Millisecond)
+		if err := sleepCtx(ctx, p.Scroll.ScrollDelay(rand.Intn(p.Scroll.DelayJitterMs+1))); err != nil {
+			return nil, err
+		}
 	}
 
-	finalItems := make([]domain.StoryItem, 0, len(itemsSet))
-	for _, item := range itemsSet {
-		finalItems = append(finalItems, item)
+	finalItems := make([]domain.StoryItem, 0, len(order))
+	for _, id := range order {
+		finalItems = append(finalItems, itemsSet[id])
 	}
+	// Oldest first; items without a known time keep their on-page order.
+	sort.SliceStable(finalItems, func(i, j int) bool {
+		return finalItems[i].TakenAt.Before(finalItems[j].TakenAt)
+	})
 
 	if len(finalItems) == 0 {
-		return nil, fmt.Errorf("no media items found after scrolling")
+		if mediaListErr != nil {
+			return nil, newStepError(ctx, page, instagram.StepMediaList, fmt.Errorf("media list did not appear: %w", mediaListErr))
+		}
+		return nil, newStepError(ctx, page, instagram.StepDownloadButtons, fmt.Errorf("no media items found after scrolling: %w", instagram.ErrEmptyResult))
 	}
 
 	return finalItems, nil
 }
 
+// mediaTypeFromURL tells videos from photos by the file extension of a download link,
+// looking through the scraper's proxy URL to the CDN URL nested in its "uri" parameter.
+func mediaTypeFromURL(rawURL string) domain.MediaType {
+	target := rawURL
+	if parsed, err := url.Parse(rawURL); err == nil {
+		if nested := parsed.Query().Get("uri"); nested != "" {
+			target = nested
+		}
+		if nestedURL, err := url.Parse(target); err == nil {
+			switch strings.ToLower(path.Ext(nestedURL.Path)) {
+			case ".mp4", ".mov", ".webm":
+				return domain.MediaTypeVideo
+			case ".jpg", ".jpeg", ".png", ".webp", ".heic":
+				return domain.MediaTypeImage
+			}
+		}
+	}
+
+	if strings.Contains(strings.ToLower(rawURL), ".mp4") {
+		return domain.MediaTypeVideo
+	}
+	return domain.MediaTypeImage
+}
+
 func extractStoryIDFromURL(rawURL string) string {
 	parsedURL, err := url.Parse(rawURL)
 	if err != nil {
@@ -0,0 +1,261 @@
+package api_adapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/config"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/logger"
+	"github.com/playwright-community/playwright-go"
+	"go.uber.org/fx"
+)
+
+var errManagerClosed = errors.New("playwright manager is shut down")
+
+var browserLaunchOptions = playwright.BrowserTypeLaunchOptions{
+	Headless: playwright.Bool(true),
+	Args: []string{
+		"--no-sandbox",
+		"--disable-setuid-sandbox",
+		"--disable-dev-shm-usage",
+		"--disable-accelerated-2d-canvas",
+		"--no-first-run",
+		"--no-zygote",
+		"--disable-gpu",
+	},
+}
+
+var browserContextOptions = playwright.BrowserNewContextOptions{
+	UserAgent: playwright.String("Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.0.0 Safari/537.36"),
+}
+
+// pooledContext is a warm browser context that is reused across scrapes.
+type pooledContext struct {
+	context    playwright.BrowserContext
+	generation int
+	pages      int
+}
+
+// PlaywrightManager owns the Chromium process and a bounded pool of warm browser contexts.
+// A disconnected browser is relaunched on the next acquire, and contexts are recycled after
+// serving a fixed number of pages to keep memory growth in check.
+type PlaywrightManager struct {
+	pw       *playwright.Playwright
+	logger   logger.Logger
+	maxPages int
+
+	// slots holds one token per context that may be in use at the same time.
+	slots chan struct{}
+
+	mu         sync.Mutex
+	browser    playwright.Browser
+	generation int
+	idle       []*pooledContext
+	closed     bool
+}
+
+func NewPlaywrightManager(lc fx.Lifecycle, log logger.Logger, cfg *config.Config) (*PlaywrightManager, error) {
+	log.Info("Initializing Playwright Manager...")
+	pw, err := playwright.Run()
+	if err != nil {
+		return nil, fmt.Errorf("could not start playwright: %w", err)
+	}
+
+	poolSize := cfg.Parser.BrowserPoolSize
+	if poolSize < 1 {
+		poolSize = 1
+	}
+	maxPages := cfg.Parser.BrowserContextMaxPages
+	if maxPages < 1 {
+		maxPages = 1
+	}
+
+	manager := &PlaywrightManager{
+		pw:       pw,
+		logger:   log.WithComponent("PlaywrightManager"),
+		maxPages: maxPages,
+		slots:    make(chan struct{}, poolSize),
+	}
+
+	manager.mu.Lock()
+	err = manager.launchLocked()
+	manager.mu.Unlock()
+	if err != nil {
+		_ = pw.Stop()
+		return nil, err
+	}
+	manager.warmUp(poolSize)
+
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			log.Info("Shutting down Playwright browser...")
+			manager.shutdown()
+			if err := manager.pw.Stop(); err != nil {
+				log.Error("Failed to stop playwright", "error", err)
+				return err
+			}
+			log.Info("Playwright stopped successfully.")
+			return nil
+		},
+	})
+	log.Info("Playwright Manager initialized successfully.", "pool_size", poolSize, "context_max_pages", maxPages)
+	return manager, nil
+}
+
+// NewPage returns a page from a pooled browser context. It blocks while every context is in use.
+// The returned release function closes the page and hands the context back to the pool.
+func (pm *PlaywrightManager) NewPage(ctx context.Context) (playwright.Page, func(), error) {
+	select {
+	case pm.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, nil, fmt.Errorf("waiting for a browser context: %w", ctx.Err())
+	}
+
+	pc, err := pm.acquire()
+	if err != nil {
+		<-pm.slots
+		return nil, nil, fmt.Errorf("%w: %w", instagram.ErrProviderDown, err)
+	}
+
+	page, err := pc.context.NewPage()
+	if err != nil {
+		pm.discard(pc)
+		<-pm.slots
+		return nil, nil, fmt.Errorf("could not create new page: %w: %w", instagram.ErrProviderDown, err)
+	}
+	pc.pages++
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			if err := page.Close(); err != nil {
+				pm.logger.Warn("Failed to close page", "error", err)
+			}
+			pm.release(pc)
+			<-pm.slots
+		})
+	}
+
+	return page, release, nil
+}
+
+// acquire hands out an idle context, relaunching the browser first if it has disconnected.
+func (pm *PlaywrightManager) acquire() (*pooledContext, error) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	if pm.closed {
+		return nil, errManagerClosed
+	}
+
+	if !pm.browser.IsConnected() {
+		pm.logger.Warn("Browser is disconnected, relaunching")
+		if err := pm.launchLocked(); err != nil {
+			return nil, err
+		}
+	}
+
+	for len(pm.idle) > 0 {
+		pc := pm.idle[len(pm.idle)-1]
+		pm.idle = pm.idle[:len(pm.idle)-1]
+		if pc.generation == pm.generation {
+			return pc, nil
+		}
+		closeContext(pc, pm.logger)
+	}
+
+	return pm.newContextLocked()
+}
+
+func (pm *PlaywrightManager) release(pc *pooledContext) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	switch {
+	case pm.closed, pc.generation != pm.generation, !pm.browser.IsConnected():
+		closeContext(pc, pm.logger)
+	case pc.pages >= pm.maxPages:
+		pm.logger.Info("Recycling browser context", "pages_served", pc.pages)
+		closeContext(pc, pm.logger)
+	default:
+		pm.idle = append(pm.idle, pc)
+	}
+}
+
+func (pm *PlaywrightManager) discard(pc *pooledContext) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	closeContext(pc, pm.logger)
+}
+
+// warmUp pre-creates contexts so the first scrapes do not pay the start-up cost.
+func (pm *PlaywrightManager) warmUp(n int) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	for i := 0; i < n; i++ {
+		pc, err := pm.newContextLocked()
+		if err != nil {
+			pm.logger.Warn("Could not warm up browser context", "error", err)
+			return
+		}
+		pm.idle = append(pm.idle, pc)
+	}
+}
+
+func (pm *PlaywrightManager) newContextLocked() (*pooledContext, error) {
+	brContext, err := pm.browser.NewContext(browserContextOptions)
+	if err != nil {
+		return nil, fmt.Errorf("could not create browser context: %w", err)
+	}
+	return &pooledContext{context: brContext, generation: pm.generation}, nil
+}
+
+// launchLocked starts a fresh browser and invalidates every context of the previous one.
+func (pm *PlaywrightManager) launchLocked() error {
+	if pm.browser != nil {
+		_ = pm.browser.Close()
+	}
+	for _, pc := range pm.idle {
+		closeContext(pc, pm.logger)
+	}
+	pm.idle = nil
+
+	browser, err := pm.pw.Chromium.Launch(browserLaunchOptions)
+	if err != nil {
+		return fmt.Errorf("could not launch browser: %w", err)
+	}
+
+	pm.generation++
+	generation := pm.generation
+	browser.On("disconnected", func() {
+		pm.logger.Warn("Browser disconnected", "generation", generation)
+	})
+	pm.browser = browser
+	pm.logger.Info("Browser launched", "generation", generation)
+	return nil
+}
+
+func (pm *PlaywrightManager) shutdown() {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	pm.closed = true
+	for _, pc := range pm.idle {
+		closeContext(pc, pm.logger)
+	}
+	pm.idle = nil
+	if pm.browser != nil {
+		if err := pm.browser.Close(); err != nil {
+			pm.logger.Error("Failed to close playwright browser", "error", err)
+		}
+	}
+}
+
+func closeContext(pc *pooledContext, log logger.Logger) {
+	if err := pc.context.Close(); err != nil {
+		log.Debug("Failed to close browser context", "error", err)
+	}
+}
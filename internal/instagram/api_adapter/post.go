@@ -5,125 +5,154 @@ import (
 	"fmt"
 	"math/rand"
 	"net/url"
-	"path/filepath"
-	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram/scraperprofile"
 	"github.com/orgball2608/insta-parser-telegram-bot/pkg/retry"
 	"github.com/playwright-community/playwright-go"
 )
 
 // GetUserPosts retrieves the latest posts for a user using a reliable third-party scraper.
 func (a *APIAdapter) GetUserPosts(ctx context.Context, userName string) ([]domain.PostItem, error) {
-	a.logger.Info("Fetching user posts via reliable scraper", "username", userName)
+	return a.listProfileTab(ctx, userName, "posts")
+}
+
+// GetUserReels retrieves the latest reels for a user from the scraper's reels tab. Profiles
+// without a reels tab selector do not support it.
+func (a *APIAdapter) GetUserReels(ctx context.Context, userName string) ([]domain.PostItem, error) {
+	if a.profiles.Current().Tabs.Reels == "" {
+		return nil, instagram.ErrNotSupported
+	}
+	return a.listProfileTab(ctx, userName, "reels")
+}
 
-	// We use the story downloader URL as it's a general-purpose entry point for a user profile.
-	scraperURL := fmt.Sprintf("https://instasupersave.com/en/instagram-stories/")
+// listProfileTab lists the items of the "posts" or "reels" tab of a profile.
+func (a *APIAdapter) listProfileTab(ctx context.Context, userName, tabName string) ([]domain.PostItem, error) {
+	a.logger.Info("Fetching user "+tabName+" via reliable scraper", "username", userName)
 
-	page, cleanup, err := a.newScrapingPage(ctx, scraperURL)
+	p := a.profiles.Current()
+
+	// --- Step 1 & 2: Search for the user, wait for results and handle private accounts ---
+	page, cleanup, err := a.openProfile(ctx, p, userName, tabName)
 	if err != nil {
 		return nil, err
 	}
 	defer cleanup()
 
-	// --- Step 1: Search for the user ---
-	if err = page.Type("#search-form-input", userName, playwright.PageTypeOptions{Timeout: playwright.Float(10000)}); err != nil {
-		return nil, fmt.Errorf("could not type username: %w", err)
-	}
-	time.Sleep(time.Duration(500+rand.Intn(1000)) * time.Millisecond)
-
-	clickOperation := func() error {
-		return page.Click("button.search-form__button")
-	}
-	if err = retry.Do(ctx, a.logger, "SearchButtonClick", clickOperation, retry.DefaultConfig()); err != nil {
-		return nil, fmt.Errorf("could not click search button: %w", err)
-	}
-
-	// --- Step 2: Wait for results and handle private accounts ---
-	profileSelector := ".output-profile, .error-message"
-	if _, err = page.WaitForSelector(profileSelector, playwright.PageWaitForSelectorOptions{Timeout: playwright.Float(90000)}); err != nil {
-		return nil, fmt.Errorf("profile results or error message did not load in time: %w", err)
-	}
+	return a.readProfileTab(ctx, page, p, userName, tabName)
+}
 
-	if isPrivate, _ := page.IsVisible(".error-message"); isPrivate {
-		a.logger.Warn("Account is private, cannot fetch posts", "user", userName)
-		return nil, instagram.ErrPrivateAccount
+// readProfileTab switches an opened profile to the "posts" or "reels" tab and lists its items.
+func (a *APIAdapter) readProfileTab(ctx context.Context, page playwright.Page, p *scraperprofile.Profile, userName, tabName string) ([]domain.PostItem, error) {
+	tabSelector, step := p.Tabs.Posts, instagram.StepPostsTab
+	if tabName == "reels" {
+		tabSelector, step = p.Tabs.Reels, instagram.StepReelsTab
 	}
 
-	// --- Step 3: Switch to the "posts" tab ---
-	postsTabSelector := "//button[contains(text(),'posts')]"
-	if err := page.Click(postsTabSelector); err != nil {
+	// --- Step 3: Switch to the tab ---
+	if err := page.Click(tabSelector); err != nil {
 		// Sometimes the page defaults to posts, so we check if the list is already there.
-		if visible, listErr := page.Locator("ul.profile-media-list").IsVisible(); !visible || listErr != nil {
-			return nil, fmt.Errorf("could not click 'posts' tab and no media list found: %w", err)
+		if visible, listErr := page.Locator(p.Selectors.MediaList).IsVisible(); tabName != "posts" || !visible || listErr != nil {
+			return nil, newStepError(ctx, page, step, fmt.Errorf("could not click '%s' tab and no media list found: %w", tabName, err))
 		}
 		a.logger.Info("Could not click 'posts' tab, but media list is visible. Proceeding.", "user", userName)
 	}
 
-	// --- Step 4: Wait for the post list to be populated ---
-	mediaItemSelector := "li.profile-media-list__item"
-	if _, err = page.WaitForSelector(mediaItemSelector, playwright.PageWaitForSelectorOptions{Timeout: playwright.Float(15000)}); err != nil {
-		a.logger.Warn("No posts found for user after switching to tab", "user", userName)
+	// --- Step 4: Wait for the list to be populated ---
+	mediaItemSelector := p.Selectors.MediaItem
+	if _, err := page.WaitForSelector(mediaItemSelector, playwright.PageWaitForSelectorOptions{Timeout: playwright.Float(p.Timeouts.PostListMs)}); err != nil {
+		a.logger.Warn("No "+tabName+" found for user after switching to tab", "user", userName)
 		return []domain.PostItem{}, nil // Return empty, not an error.
 	}
 
 	// --- Step 5: Extract post information ---
 	postLocators, err := page.Locator(mediaItemSelector).All()
 	if err != nil {
-		return nil, fmt.Errorf("could not get post locators: %w", err)
+		return nil, fmt.Errorf("could not get post locators: %w: %w", instagram.ErrSelectorMissing, err)
 	}
 
 	var posts []domain.PostItem
-	idRegex := regexp.MustCompile(`_(\d+)_`)
+	seen := make(map[string]bool)
 
 	for i, locator := range postLocators {
-		if i >= 12 { // Limit to 12 most recent posts, same as before
+		if len(posts) >= p.Scroll.MaxPosts { // Limit to the most recent posts
 			break
 		}
 
-		// The download link is the most reliable source for the media ID
-		downloadLink, err := locator.Locator("a.button__download").GetAttribute("href")
-		if err != nil {
-			a.logger.Warn("Could not get download link for a post, skipping", "index", i)
+		post, ok := a.resolveListedPost(locator, p, userName, tabName == "reels")
+		if !ok {
+			a.logger.Warn("Could not resolve the shortcode of a listed post, skipping", "index", i)
+			continue
+		}
+		if seen[post.ID] {
 			continue
 		}
+		seen[post.ID] = true
+		posts = append(posts, post)
+	}
+
+	a.logger.Info("Successfully fetched "+tabName+" list from scraper", "username", userName, "count", len(posts))
+	return posts, nil
+}
 
-		// Extract a unique ID from the download URL.
-		// Example: .../508714993_18309646888214125_1467041143115731382_n.jpg
-		// We can use the middle part as a unique ID.
-		matches := idRegex.FindStringSubmatch(downloadLink)
-		var postID string
-		if len(matches) > 1 {
-			postID = matches[1]
-		} else {
-			// Fallback: if regex fails, use a less reliable part of the URL
-			parts := strings.Split(filepath.Base(downloadLink), "_")
-			if len(parts) > 1 {
-				postID = parts[1]
-			} else {
-				a.logger.Warn("Could not determine a unique ID for post, skipping", "index", i, "url", downloadLink)
+// resolveListedPost finds the real shortcode of a post in the posts tab, preferring an
+// Instagram permalink in the item and falling back to the media ID encoded in the download
+// link. The shortcode is used as the post ID so deduplication is stable across runs. Items of
+// the reels tab are always reels.
+func (a *APIAdapter) resolveListedPost(locator playwright.Locator, p *scraperprofile.Profile, userName string, isReel bool) (domain.PostItem, bool) {
+	var shortcode, mediaID string
+
+	if p.Selectors.Permalink != "" {
+		links, _ := locator.Locator(p.Selectors.Permalink).All()
+		for _, link := range links {
+			href, err := link.GetAttribute("href")
+			if err != nil {
 				continue
 			}
+			if code, reel, ok := instagram.ShortcodeFromURL(href); ok {
+				shortcode, isReel = code, isReel || reel
+				break
+			}
 		}
+	}
 
-		// Construct a pseudo Post URL since the scraper doesn't provide the shortcode.
-		// This is okay for the subscription feature, as we only need a unique URL to pass to GetUserPost.
-		pseudoPostURL := fmt.Sprintf("https://www.instagram.com/p/%s/", postID)
+	downloadLinks, _ := locator.Locator(p.Selectors.DownloadButton).All()
+	for _, link := range downloadLinks {
+		href, err := link.GetAttribute("href")
+		if err != nil || href == "" {
+			continue
+		}
+		if id, ok := instagram.MediaIDFromURL(href); ok {
+			mediaID = id
+			break
+		}
+	}
 
-		posts = append(posts, domain.PostItem{
-			ID:       postID,
-			PostURL:  pseudoPostURL, // Use the generated URL
-			URL:      pseudoPostURL, // Keep URL field for compatibility
-			Username: userName,
-		})
+	if shortcode == "" {
+		code, ok := instagram.ShortcodeFromMediaID(mediaID)
+		if !ok {
+			return domain.PostItem{}, false
+		}
+		shortcode = code
 	}
 
-	a.logger.Info("Successfully fetched post list from scraper", "username", userName, "count", len(posts))
-	return posts, nil
+	permalink := instagram.Permalink(shortcode, isReel)
+	post := domain.PostItem{
+		ID:       shortcode,
+		PostURL:  permalink,
+		URL:      permalink,
+		Username: userName,
+		IsReel:   isReel,
+	}
+	if takenAt, ok := instagram.TimeFromMediaID(mediaID); ok {
+		post.TakenAt = takenAt
+	}
+
+	return post, true
 }
 
 func normalizePostURL(rawURL string) (string, error) {
@@ -150,13 +179,15 @@ func (a *APIAdapter) GetUserPost(ctx context.Context, postURL string) (*domain.P
 }
 
 // Helper function to scroll the page to load more content
-func scrollPageToLoadMore(page playwright.Page, scrollCount int) error {
+func scrollPageToLoadMore(ctx context.Context, page playwright.Page, scrollCount int) error {
 	for i := 0; i < scrollCount; i++ {
 		_, err := page.Evaluate(`window.scrollTo(0, document.body.scrollHeight)`)
 		if err != nil {
 			return err
 		}
-		time.Sleep(2 * time.Second)
+		if err := sleepCtx(ctx, 2*time.Second); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -176,75 +207,81 @@ func extractPostIDFromURL(url string) string {
 func (a *APIAdapter) scrapeMedia(ctx context.Context, mediaURL string, mediaType string) (*domain.PostItem, error) {
 	a.logger.Info("Scraping media", "type", mediaType, "url", mediaURL)
 
-	scraperURL := "https://instasupersave.com/en/instagram-video/"
+	p := a.profiles.Current()
 
-	page, cleanup, err := a.newScrapingPage(ctx, scraperURL)
+	page, cleanup, err := a.newScrapingPage(ctx, p, p.URLs.Media)
 	if err != nil {
 		return nil, err
 	}
 	defer cleanup()
 
-	cookieButtonSelector := "button.button.cookie-policy__button"
+	cookieButtonSelector := p.Selectors.CookieButton
 	if isVisible, _ := page.IsVisible(cookieButtonSelector); isVisible {
 		a.logger.Info("Cookie policy button found, clicking it.")
-		if err := page.Click(cookieButtonSelector, playwright.PageClickOptions{Timeout: playwright.Float(5000)}); err != nil {
+		if err := page.Click(cookieButtonSelector, playwright.PageClickOptions{Timeout: playwright.Float(p.Timeouts.ClickMs)}); err != nil {
 			a.logger.Warn("Could not click cookie policy button, proceeding anyway.", "error", err)
 		}
 	}
 
-	inputSelector := "#search-form-input"
-	submitButtonSelector := "button.search-form__button"
-	timeout := float64(30000)
+	inputSelector := p.Selectors.SearchInput
+	submitButtonSelector := p.Selectors.SearchButton
 
 	if _, err = page.WaitForSelector(inputSelector, playwright.PageWaitForSelectorOptions{
 		State:   playwright.WaitForSelectorStateVisible,
-		Timeout: playwright.Float(timeout),
+		Timeout: playwright.Float(p.Timeouts.InputVisibleMs),
 	}); err != nil {
-		return nil, fmt.Errorf("input field '%s' not visible: %w", inputSelector, err)
+		return nil, newStepError(ctx, page, instagram.StepSearchForm, fmt.Errorf("input field '%s' not visible: %w", inputSelector, err))
 	}
 
-	if err = page.Type(inputSelector, mediaURL, playwright.PageTypeOptions{Timeout: playwright.Float(10000)}); err != nil {
-		return nil, fmt.Errorf("could not type %s URL: %w", mediaType, err)
+	if err = page.Type(inputSelector, mediaURL, playwright.PageTypeOptions{Timeout: playwright.Float(p.Timeouts.TypeMs)}); err != nil {
+		return nil, newStepError(ctx, page, instagram.StepSearchForm, fmt.Errorf("could not type %s URL: %w", mediaType, err))
 	}
 
-	time.Sleep(time.Duration(500+rand.Intn(500)) * time.Millisecond)
+	if err = sleepCtx(ctx, time.Duration(500+rand.Intn(500))*time.Millisecond); err != nil {
+		return nil, err
+	}
 
 	clickOperation := func() error {
-		return page.Click(submitButtonSelector)
+		return retryableBrowserError(page.Click(submitButtonSelector))
 	}
 	if err = retry.Do(ctx, a.logger, "SearchMediaClick", clickOperation, retry.DefaultConfig()); err != nil {
-		return nil, fmt.Errorf("could not click search button for %s: %w", mediaType, err)
+		return nil, newStepError(ctx, page, instagram.StepSearchForm, fmt.Errorf("could not click search button for %s: %w", mediaType, err))
 	}
 
-	resultSelector := "div.output-list, .output-component, .error-message"
-	if _, err = page.WaitForSelector(resultSelector, playwright.PageWaitForSelectorOptions{Timeout: playwright.Float(90000)}); err != nil {
-		screenshotPath := fmt.Sprintf("tmp/error_screenshot_%s_%d.png", mediaType, time.Now().Unix())
-		page.Screenshot(playwright.PageScreenshotOptions{Path: playwright.String(screenshotPath), FullPage: playwright.Bool(true)})
-		a.logger.Error("Timeout waiting for media result, screenshot saved", "path", screenshotPath, "error", err)
-		return nil, fmt.Errorf("%s results or error message did not load in time: %w", mediaType, err)
+	resultSelector := p.Selectors.MediaResult + ", " + p.Selectors.ErrorMessage
+	if _, err = page.WaitForSelector(resultSelector, playwright.PageWaitForSelectorOptions{Timeout: playwright.Float(p.Timeouts.SearchResultsMs)}); err != nil {
+		stepErr := newStepError(ctx, page, instagram.StepMediaResult, fmt.Errorf("%s results or error message did not load in time: %w", mediaType, err))
+		a.logger.Error("Timeout waiting for media result", "error", stepErr)
+		return nil, stepErr
 	}
 
-	if isError, _ := page.IsVisible(".error-message"); isError {
-		errorText, _ := page.InnerText(".error-message")
+	if isError, _ := page.IsVisible(p.Selectors.ErrorMessage); isError {
+		errorText, _ := page.InnerText(p.Selectors.ErrorMessage)
 		a.logger.Warn("Error message displayed for media", "url", mediaURL, "message", errorText)
-		return nil, fmt.Errorf("failed to get %s: %s", mediaType, errorText)
+		return nil, fmt.Errorf("failed to get %s: %s: %w", mediaType, errorText, instagram.ErrNotFound)
 	}
 
-	mediaItem := &domain.PostItem{PostURL: mediaURL}
+	mediaItem := &domain.PostItem{PostURL: mediaURL, URL: mediaURL}
+	if shortcode, isReel, ok := instagram.ShortcodeFromURL(mediaURL); ok {
+		mediaItem.ID = shortcode
+		mediaItem.IsReel = isReel || mediaType == "reel"
+		mediaItem.PostURL = instagram.Permalink(shortcode, mediaItem.IsReel)
+		mediaItem.URL = mediaItem.PostURL
+	}
 
-	if caption, err := page.InnerText(".output-list__caption p"); err == nil {
+	if caption, err := page.InnerText(p.Selectors.Caption); err == nil {
 		mediaItem.Caption = caption
 	} else {
 		a.logger.Warn("Could not find media caption", "url", mediaURL, "error", err)
 	}
 
-	if avatarHref, err := page.GetAttribute(".output-list__user-avatar", "href"); err == nil {
+	if avatarHref, err := page.GetAttribute(p.Selectors.AuthorAvatar, "href"); err == nil {
 		if u, err := url.Parse(avatarHref); err == nil {
 			mediaItem.Username = strings.Trim(u.Path, "/")
 		}
 	}
 
-	if likesText, err := page.InnerText(".output-list__info-like"); err == nil {
+	if likesText, err := page.InnerText(p.Selectors.Likes); err == nil {
 		parts := strings.Fields(likesText)
 		if len(parts) > 0 {
 			if likeCount, err := strconv.Atoi(strings.ReplaceAll(parts[0], ",", "")); err == nil {
@@ -253,40 +290,54 @@ func (a *APIAdapter) scrapeMedia(ctx context.Context, mediaURL string, mediaType
 		}
 	}
 
-	if postedAgoText, err := page.InnerText(".output-list__info-time"); err == nil {
+	if postedAgoText, err := page.InnerText(p.Selectors.PostedAgo); err == nil {
 		mediaItem.PostedAgo = strings.TrimSpace(postedAgoText)
 	}
 
-	downloadLocators, err := page.Locator("a.button__download").All()
+	downloadLocators, err := page.Locator(p.Selectors.DownloadButton).All()
 	if err != nil {
-		return nil, fmt.Errorf("could not find download buttons: %w", err)
+		return nil, newStepError(ctx, page, instagram.StepDownloadButtons, fmt.Errorf("could not find download buttons: %w", err))
 	}
 
 	if len(downloadLocators) == 0 {
-		return nil, fmt.Errorf("no download links found on the page")
+		return nil, newStepError(ctx, page, instagram.StepDownloadButtons, fmt.Errorf("no download links found on the page: %w", instagram.ErrEmptyResult))
 	}
 
-	var mediaURLs []string
 	for _, locator := range downloadLocators {
 		href, err := locator.GetAttribute("href")
-		if err == nil && href != "" {
-			mediaURLs = append(mediaURLs, href)
+		if err != nil || href == "" {
+			continue
 		}
-	}
-	mediaItem.MediaURLs = mediaURLs
 
-	if mediaType == "reel" {
-		mediaItem.IsVideo = true
-	} else {
-		for _, url := range mediaURLs {
-			if strings.Contains(url, ".mp4") {
-				mediaItem.IsVideo = true
-				break
-			}
+		itemType := mediaTypeFromURL(href)
+		if mediaType == "reel" {
+			itemType = domain.MediaTypeVideo
+		}
+
+		item := domain.NewMediaItem(itemType, []domain.MediaResolution{{URL: href}})
+		if item.IsVideo() && p.Selectors.ResultItem != "" {
+			item.ThumbnailURL = resultThumbnail(locator, p.Selectors.ResultItem)
 		}
+		mediaItem.Media = append(mediaItem.Media, item)
 	}
 
-	a.logger.Info("Successfully scraped media", "type", mediaType, "url", mediaURL, "media_count", len(mediaItem.MediaURLs), "likes", mediaItem.LikeCount, "posted_ago", mediaItem.PostedAgo)
+	a.logger.Info("Successfully scraped media", "type", mediaType, "url", mediaURL, "media_count", len(mediaItem.Media), "likes", mediaItem.LikeCount, "posted_ago", mediaItem.PostedAgo)
 
 	return mediaItem, nil
 }
+
+// resultThumbnailScript returns the preview image shown next to a download button.
+const resultThumbnailScript = `(el, itemSelector) => {
+	const item = el.closest(itemSelector);
+	const img = item && item.querySelector("img");
+	return img ? img.src : "";
+}`
+
+func resultThumbnail(locator playwright.Locator, itemSelector string) string {
+	raw, err := locator.Evaluate(resultThumbnailScript, itemSelector)
+	if err != nil {
+		return ""
+	}
+	src, _ := raw.(string)
+	return src
+}
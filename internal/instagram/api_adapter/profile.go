@@ -0,0 +1,136 @@
+package api_adapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram/scraperprofile"
+	"github.com/playwright-community/playwright-go"
+)
+
+// profileStatsScript returns the label and value of every counter in the profile block.
+const profileStatsScript = `(el, [statSelector, valueSelector]) => {
+	return Array.from(el.querySelectorAll(statSelector)).map((stat) => {
+		const value = stat.querySelector(valueSelector);
+		const valueText = value ? value.textContent : "";
+		const label = (stat.textContent || "").replace(valueText, "");
+		return [label.trim(), valueText.trim()];
+	});
+}`
+
+// GetUserProfile reads the account's metadata from the profile block the scraper site
+// shows after every search. Private accounts only yield the username.
+func (a *APIAdapter) GetUserProfile(ctx context.Context, userName string) (*domain.UserProfile, error) {
+	a.logger.Info("Fetching profile via scraper", "user", userName)
+
+	p := a.profiles.Current()
+
+	page, cleanup, err := a.openProfile(ctx, p, userName, "profile")
+	if errors.Is(err, instagram.ErrPrivateAccount) {
+		return &domain.UserProfile{Username: userName, IsPrivate: true}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	return a.readProfileHeader(ctx, page, p, userName)
+}
+
+// readProfileHeader reads the profile block of a page on which userName was searched.
+func (a *APIAdapter) readProfileHeader(ctx context.Context, page playwright.Page, p *scraperprofile.Profile, userName string) (*domain.UserProfile, error) {
+	block := page.Locator(p.Selectors.ProfileResult).First()
+	profile := &domain.UserProfile{Username: userName}
+
+	avatar := block.Locator(p.Selectors.ProfileAvatar).First()
+	var err error
+	if profile.AvatarURL, err = avatar.GetAttribute("src"); err != nil || profile.AvatarURL == "" {
+		return nil, newStepError(ctx, page, instagram.StepProfileHeader, fmt.Errorf("could not read profile avatar: %w", err))
+	}
+
+	profile.FullName = optionalText(block, p.Selectors.ProfileFullName)
+	profile.Biography = optionalText(block, p.Selectors.ProfileBio)
+	if p.Selectors.ProfileVerified != "" {
+		if count, err := block.Locator(p.Selectors.ProfileVerified).Count(); err == nil {
+			profile.IsVerified = count > 0
+		}
+	}
+
+	raw, err := block.Evaluate(profileStatsScript, []string{p.Selectors.ProfileStat, p.Selectors.ProfileStatValue})
+	if err != nil {
+		return nil, newStepError(ctx, page, instagram.StepProfileHeader, fmt.Errorf("could not read profile counters: %w", err))
+	}
+	stats, _ := raw.([]interface{})
+	for _, entry := range stats {
+		pair, ok := entry.([]interface{})
+		if !ok || len(pair) != 2 {
+			continue
+		}
+		label, _ := pair[0].(string)
+		value, _ := pair[1].(string)
+		count, ok := parseCount(value)
+		if !ok {
+			continue
+		}
+
+		switch label = strings.ToLower(label); {
+		case strings.Contains(label, "follower"):
+			profile.FollowerCount = count
+		case strings.Contains(label, "following"):
+			profile.FollowingCount = count
+		case strings.Contains(label, "post"):
+			profile.PostCount = count
+		}
+	}
+
+	return profile, nil
+}
+
+// optionalText returns the trimmed text of the first match of selector inside block, or an
+// empty string when it is missing.
+func optionalText(block playwright.Locator, selector string) string {
+	loc := block.Locator(selector).First()
+	if count, err := loc.Count(); err != nil || count == 0 {
+		return ""
+	}
+	text, err := loc.InnerText()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(text)
+}
+
+// parseCount understands counters as the scraper site renders them: "12,345", "1 234",
+// "12.3K" or "1.2M".
+func parseCount(raw string) (int, bool) {
+	text := strings.ToUpper(strings.TrimSpace(raw))
+	text = strings.NewReplacer(",", "", " ", "", " ", "").Replace(text)
+	if text == "" {
+		return 0, false
+	}
+
+	multiplier := 1.0
+	switch {
+	case strings.HasSuffix(text, "K"):
+		multiplier = 1e3
+	case strings.HasSuffix(text, "M"):
+		multiplier = 1e6
+	case strings.HasSuffix(text, "B"):
+		multiplier = 1e9
+	}
+	if multiplier != 1 {
+		text = text[:len(text)-1]
+	}
+
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil || value < 0 {
+		return 0, false
+	}
+	return int(math.Round(value * multiplier)), true
+}
@@ -0,0 +1,45 @@
+package api_adapter
+
+import (
+	"context"
+	"errors"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram"
+)
+
+// GetProfileSnapshot searches for the account once and reads the profile header and the
+// stories, posts and highlights tabs from the same page, instead of one search per tab.
+func (a *APIAdapter) GetProfileSnapshot(ctx context.Context, userName string) (*domain.AccountSnapshot, error) {
+	a.logger.Info("Scraping profile snapshot", "user", userName)
+
+	p := a.profiles.Current()
+	page, cleanup, err := a.openProfile(ctx, p, userName, "snapshot")
+	if errors.Is(err, instagram.ErrPrivateAccount) {
+		return &domain.AccountSnapshot{Profile: domain.UserProfile{Username: userName, IsPrivate: true}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	profile, err := a.readProfileHeader(ctx, page, p, userName)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &domain.AccountSnapshot{Profile: *profile}
+
+	if snapshot.Stories, err = a.readStories(ctx, page, p, userName); err != nil {
+		return nil, err
+	}
+	if snapshot.Posts, err = a.readProfileTab(ctx, page, p, userName, "posts"); err != nil {
+		return nil, err
+	}
+	if snapshot.Highlights, err = a.readHighlightPreviews(ctx, page, p, userName); err != nil {
+		return nil, err
+	}
+
+	a.logger.Info("Scraped profile snapshot", "user", userName,
+		"stories", len(snapshot.Stories), "posts", len(snapshot.Posts), "highlights", len(snapshot.Highlights))
+	return snapshot, nil
+}
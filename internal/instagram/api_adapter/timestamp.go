@@ -0,0 +1,106 @@
+package api_adapter
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram"
+	"github.com/playwright-community/playwright-go"
+)
+
+// absoluteTimeLayouts are the absolute date formats the scraper site has been seen to render.
+var absoluteTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"1/2/2006, 3:04:05 PM",
+	"1/2/2006, 15:04:05",
+	"02.01.2006, 15:04:05",
+	"Jan 2, 2006",
+	"January 2, 2006",
+}
+
+var relativeTimeRegex = regexp.MustCompile(`(?i)^(\d+|an?|one)\s+(second|minute|hour|day|week|month|year)s?\s+ago$`)
+
+// mediaItemTimeScript returns the title attribute (preferred, usually absolute) or the text of
+// the time element inside the list item that contains the download button.
+const mediaItemTimeScript = `(el, [itemSelector, timeSelector]) => {
+	const item = el.closest(itemSelector);
+	const node = item && item.querySelector(timeSelector);
+	if (!node) return "";
+	return node.getAttribute("datetime") || node.getAttribute("title") || node.textContent || "";
+}`
+
+// resolveTakenAt finds when a listed story or highlight item was posted: first from the time
+// shown on the page, then from the media ID embedded in its URL, falling back to now.
+func resolveTakenAt(locator playwright.Locator, itemSelector, timeSelector, href string, now time.Time) time.Time {
+	if timeSelector != "" {
+		if raw, err := locator.Evaluate(mediaItemTimeScript, []string{itemSelector, timeSelector}); err == nil {
+			if text, ok := raw.(string); ok {
+				if t, ok := parsePostedTime(text, now); ok {
+					return t
+				}
+			}
+		}
+	}
+
+	if mediaID, ok := instagram.MediaIDFromURL(href); ok {
+		if t, ok := instagram.TimeFromMediaID(mediaID); ok {
+			return t
+		}
+	}
+
+	return now
+}
+
+// parsePostedTime understands absolute dates and English relative times such as "3 hours ago".
+func parsePostedTime(raw string, now time.Time) (time.Time, bool) {
+	text := strings.TrimSpace(raw)
+	if text == "" {
+		return time.Time{}, false
+	}
+
+	for _, layout := range absoluteTimeLayouts {
+		if t, err := time.Parse(layout, text); err == nil {
+			return t, true
+		}
+	}
+
+	lower := strings.ToLower(text)
+	switch lower {
+	case "just now", "now":
+		return now, true
+	case "yesterday":
+		return now.AddDate(0, 0, -1), true
+	}
+
+	match := relativeTimeRegex.FindStringSubmatch(lower)
+	if match == nil {
+		return time.Time{}, false
+	}
+
+	amount := 1
+	if n, err := strconv.Atoi(match[1]); err == nil {
+		amount = n
+	}
+
+	switch match[2] {
+	case "second":
+		return now.Add(-time.Duration(amount) * time.Second), true
+	case "minute":
+		return now.Add(-time.Duration(amount) * time.Minute), true
+	case "hour":
+		return now.Add(-time.Duration(amount) * time.Hour), true
+	case "day":
+		return now.AddDate(0, 0, -amount), true
+	case "week":
+		return now.AddDate(0, 0, -7*amount), true
+	case "month":
+		return now.AddDate(0, -amount, 0), true
+	default:
+		return now.AddDate(-amount, 0, 0), true
+	}
+}
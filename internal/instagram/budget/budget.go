@@ -0,0 +1,292 @@
+package budget
+
+import (
+	"context"
+	"sync"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/config"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/logger"
+)
+
+// waiter is a caller queued for a slot. Its ready channel is closed once a slot is handed to it.
+type waiter struct {
+	ready        chan struct{}
+	observer     QueueObserver
+	positions    chan int      // Latest position not yet passed to the observer
+	reported     chan struct{} // Closed once the observer is no longer called
+	lastPosition int
+}
+
+func newWaiter(observer QueueObserver) *waiter {
+	w := &waiter{ready: make(chan struct{}), observer: observer}
+	if observer != nil {
+		w.positions = make(chan int, 1)
+		w.reported = make(chan struct{})
+		go w.report()
+	}
+	return w
+}
+
+// report passes positions to the observer until the waiter leaves the queue. Observers make
+// network calls, so they run here rather than on the goroutine handing over a slot.
+func (w *waiter) report() {
+	defer close(w.reported)
+	for position := range w.positions {
+		w.observer(position)
+	}
+}
+
+// wait returns once the observer has returned for the last time, so a position report can't
+// land after the caller moved on, e.g. overwrite the message the observer edits. It must be
+// called after leave, without b.mu held.
+func (w *waiter) wait() {
+	if w.reported != nil {
+		<-w.reported
+	}
+}
+
+// publish replaces any undelivered position with position. b.mu must be held.
+func (w *waiter) publish(position int) {
+	select {
+	case <-w.positions:
+	default:
+	}
+	w.positions <- position
+}
+
+// leave stops the reports once the waiter got a slot or gave up, dropping a stale position.
+// b.mu must be held.
+func (w *waiter) leave() {
+	if w.positions == nil {
+		return
+	}
+	select {
+	case <-w.positions:
+	default:
+	}
+	close(w.positions)
+}
+
+// Budget is an instagram.Client that caps the number of scraper calls in flight across the
+// whole process. Callers over the cap queue up; interactive callers are served first and
+// callers of the same priority in arrival order.
+type Budget struct {
+	next   instagram.Client
+	logger logger.Logger
+	size   int
+
+	mu          sync.Mutex
+	inUse       int
+	interactive []*waiter
+	background  []*waiter
+}
+
+var _ instagram.Client = (*Budget)(nil)
+
+// New wraps client in a budget of Config.Parser.ScrapeConcurrency concurrent calls.
+func New(client instagram.Client, cfg *config.Config, logger logger.Logger) instagram.Client {
+	size := cfg.Parser.ScrapeConcurrency
+	if size < 1 {
+		size = 1
+	}
+
+	log := logger.WithComponent("ScraperBudget")
+	log.Info("Scraper concurrency budget configured", "slots", size)
+
+	return &Budget{
+		next:   client,
+		logger: log,
+		size:   size,
+	}
+}
+
+// acquire takes a slot, waiting in the queue of the context's priority while none is free.
+func (b *Budget) acquire(ctx context.Context) error {
+	b.mu.Lock()
+	// Waiters are only queued while every slot is taken, so a free slot can be taken at once
+	if b.inUse < b.size {
+		b.inUse++
+		b.mu.Unlock()
+		return nil
+	}
+
+	var w *waiter
+	priority := priorityFrom(ctx)
+	shared := sharedFrom(ctx)
+	if shared != nil {
+		// Observed even without observers yet, so callers that join later learn the position
+		w = newWaiter(shared.observe)
+		priority = shared.watch(func() { b.promote(w) })
+		defer shared.watch(nil)
+	} else {
+		w = newWaiter(observerFrom(ctx))
+	}
+	if priority == PriorityInteractive {
+		b.interactive = append(b.interactive, w)
+	} else {
+		b.background = append(b.background, w)
+	}
+	b.publishPositions()
+	b.mu.Unlock()
+
+	b.logger.Debug("Waiting for a scraper slot", "priority", priority)
+
+	select {
+	case <-w.ready:
+		w.wait()
+		return nil
+	case <-ctx.Done():
+	}
+
+	b.mu.Lock()
+	if b.remove(w) {
+		w.leave()
+		b.publishPositions()
+		b.mu.Unlock()
+		w.wait()
+		return ctx.Err()
+	}
+	b.mu.Unlock()
+
+	// The slot was handed over just as the context ended, so pass it on
+	b.release()
+	w.wait()
+	return ctx.Err()
+}
+
+// release hands the slot to the next waiter or frees it.
+func (b *Budget) release() {
+	b.mu.Lock()
+	var next *waiter
+	switch {
+	case len(b.interactive) > 0:
+		next, b.interactive = b.interactive[0], b.interactive[1:]
+	case len(b.background) > 0:
+		next, b.background = b.background[0], b.background[1:]
+	default:
+		b.inUse--
+	}
+	if next != nil {
+		next.leave()
+		close(next.ready)
+		b.publishPositions()
+	}
+	b.mu.Unlock()
+}
+
+// promote moves a waiter queued at background priority to the end of the interactive queue,
+// for a shared call that an interactive caller joined.
+func (b *Budget) promote(w *waiter) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for i, queued := range b.background {
+		if queued == w {
+			b.background = append(b.background[:i], b.background[i+1:]...)
+			b.interactive = append(b.interactive, w)
+			b.logger.Debug("Shared scraper call promoted to interactive priority")
+			// Reported even if unchanged, since the caller that promoted it has not heard it yet
+			w.lastPosition = 0
+			b.publishPositions()
+			return
+		}
+	}
+}
+
+// remove drops w from its queue and reports whether it was still queued. b.mu must be held.
+func (b *Budget) remove(w *waiter) bool {
+	for _, queue := range []*[]*waiter{&b.interactive, &b.background} {
+		for i, queued := range *queue {
+			if queued == w {
+				*queue = append((*queue)[:i], (*queue)[i+1:]...)
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// publishPositions hands the current position of every observed waiter whose position
+// changed to its reporter. It never blocks. b.mu must be held.
+func (b *Budget) publishPositions() {
+	for i, w := range append(append([]*waiter{}, b.interactive...), b.background...) {
+		position := i + 1
+		if w.observer == nil || w.lastPosition == position {
+			continue
+		}
+		w.lastPosition = position
+		w.publish(position)
+	}
+}
+
+func withSlot[T any](ctx context.Context, b *Budget, fn func() (T, error)) (T, error) {
+	if err := b.acquire(ctx); err != nil {
+		var zero T
+		return zero, err
+	}
+	defer b.release()
+	return fn()
+}
+
+func (b *Budget) GetUserStories(ctx context.Context, userName string) ([]domain.StoryItem, error) {
+	return withSlot(ctx, b, func() ([]domain.StoryItem, error) {
+		return b.next.GetUserStories(ctx, userName)
+	})
+}
+
+func (b *Budget) GetUserHighlights(ctx context.Context, userName string, processorFunc instagram.HighlightReelProcessorFunc) error {
+	_, err := withSlot(ctx, b, func() (struct{}, error) {
+		return struct{}{}, b.next.GetUserHighlights(ctx, userName, processorFunc)
+	})
+	return err
+}
+
+func (b *Budget) GetHighlightAlbumPreviews(ctx context.Context, userName string) ([]domain.HighlightAlbumPreview, error) {
+	return withSlot(ctx, b, func() ([]domain.HighlightAlbumPreview, error) {
+		return b.next.GetHighlightAlbumPreviews(ctx, userName)
+	})
+}
+
+func (b *Budget) GetSingleHighlightAlbum(ctx context.Context, userName, albumID string) (*domain.HighlightReel, error) {
+	return withSlot(ctx, b, func() (*domain.HighlightReel, error) {
+		return b.next.GetSingleHighlightAlbum(ctx, userName, albumID)
+	})
+}
+
+func (b *Budget) GetUserPost(ctx context.Context, postURL string) (*domain.PostItem, error) {
+	return withSlot(ctx, b, func() (*domain.PostItem, error) {
+		return b.next.GetUserPost(ctx, postURL)
+	})
+}
+
+func (b *Budget) GetUserReel(ctx context.Context, reelURL string) (*domain.PostItem, error) {
+	return withSlot(ctx, b, func() (*domain.PostItem, error) {
+		return b.next.GetUserReel(ctx, reelURL)
+	})
+}
+
+func (b *Budget) GetUserPosts(ctx context.Context, userName string) ([]domain.PostItem, error) {
+	return withSlot(ctx, b, func() ([]domain.PostItem, error) {
+		return b.next.GetUserPosts(ctx, userName)
+	})
+}
+
+func (b *Budget) GetUserReels(ctx context.Context, userName string) ([]domain.PostItem, error) {
+	return withSlot(ctx, b, func() ([]domain.PostItem, error) {
+		return b.next.GetUserReels(ctx, userName)
+	})
+}
+
+func (b *Budget) GetUserProfile(ctx context.Context, userName string) (*domain.UserProfile, error) {
+	return withSlot(ctx, b, func() (*domain.UserProfile, error) {
+		return b.next.GetUserProfile(ctx, userName)
+	})
+}
+
+func (b *Budget) GetProfileSnapshot(ctx context.Context, userName string) (*domain.AccountSnapshot, error) {
+	return withSlot(ctx, b, func() (*domain.AccountSnapshot, error) {
+		return b.next.GetProfileSnapshot(ctx, userName)
+	})
+}
@@ -0,0 +1,293 @@
+package budget
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/config"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/logger"
+)
+
+func newTestBudget(t *testing.T, slots int) *Budget {
+	t.Helper()
+	cfg := &config.Config{Parser: config.ParserConfig{ScrapeConcurrency: slots}}
+	return New(nil, cfg, logger.New(logger.Opts{Env: "test"})).(*Budget)
+}
+
+// waitQueued waits until n callers are queued.
+func waitQueued(t *testing.T, b *Budget, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for {
+		b.mu.Lock()
+		queued := len(b.interactive) + len(b.background)
+		b.mu.Unlock()
+		if queued == n {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d callers queued, want %d", queued, n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// acquireAsync takes a slot on another goroutine and sends name once it got one.
+func acquireAsync(ctx context.Context, b *Budget, name string, acquired chan<- string, errs chan<- error) {
+	go func() {
+		if err := b.acquire(ctx); err != nil {
+			errs <- err
+			return
+		}
+		acquired <- name
+	}()
+}
+
+func receive[T any](t *testing.T, ch <-chan T) T {
+	t.Helper()
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(time.Second):
+		t.Fatal("timed out")
+		panic("unreachable")
+	}
+}
+
+// positionRecorder is a queue observer that keeps every reported position.
+type positionRecorder struct {
+	mu        sync.Mutex
+	positions []int
+	changed   chan struct{}
+}
+
+func newPositionRecorder() *positionRecorder {
+	return &positionRecorder{changed: make(chan struct{}, 100)}
+}
+
+func (r *positionRecorder) observe(position int) {
+	r.mu.Lock()
+	r.positions = append(r.positions, position)
+	r.mu.Unlock()
+	r.changed <- struct{}{}
+}
+
+// waitFor waits until the last reported position is want.
+func (r *positionRecorder) waitFor(t *testing.T, want int) {
+	t.Helper()
+	for {
+		r.mu.Lock()
+		n := len(r.positions)
+		last := 0
+		if n > 0 {
+			last = r.positions[n-1]
+		}
+		r.mu.Unlock()
+		if last == want {
+			return
+		}
+		receive(t, r.changed)
+	}
+}
+
+func TestInteractiveCallersGoFirst(t *testing.T) {
+	b := newTestBudget(t, 1)
+	if err := b.acquire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	acquired := make(chan string, 3)
+	errs := make(chan error, 3)
+	background := context.Background()
+	interactive := WithPriority(context.Background(), PriorityInteractive)
+
+	acquireAsync(background, b, "background 1", acquired, errs)
+	waitQueued(t, b, 1)
+	acquireAsync(background, b, "background 2", acquired, errs)
+	waitQueued(t, b, 2)
+	acquireAsync(interactive, b, "interactive", acquired, errs)
+	waitQueued(t, b, 3)
+
+	for _, want := range []string{"interactive", "background 1", "background 2"} {
+		b.release()
+		if got := receive(t, acquired); got != want {
+			t.Fatalf("slot went to %s, want %s", got, want)
+		}
+	}
+}
+
+func TestCancelWhileQueued(t *testing.T) {
+	b := newTestBudget(t, 1)
+	if err := b.acquire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	acquired := make(chan string, 2)
+	errs := make(chan error, 2)
+	ctx, cancel := context.WithCancel(context.Background())
+	first := newPositionRecorder()
+	second := newPositionRecorder()
+
+	acquireAsync(WithQueueObserver(ctx, first.observe), b, "cancelled", acquired, errs)
+	waitQueued(t, b, 1)
+	acquireAsync(WithQueueObserver(context.Background(), second.observe), b, "waiting", acquired, errs)
+	waitQueued(t, b, 2)
+	second.waitFor(t, 2)
+
+	cancel()
+	if err := receive(t, errs); !errors.Is(err, context.Canceled) {
+		t.Fatalf("acquire() error = %v, want %v", err, context.Canceled)
+	}
+	waitQueued(t, b, 1)
+	second.waitFor(t, 1)
+
+	b.release()
+	if got := receive(t, acquired); got != "waiting" {
+		t.Fatalf("slot went to %s, want the caller still waiting", got)
+	}
+	b.release()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.inUse != 0 {
+		t.Errorf("%d slots in use after every caller left, want 0", b.inUse)
+	}
+}
+
+func TestPositionsAreReported(t *testing.T) {
+	b := newTestBudget(t, 1)
+	if err := b.acquire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	acquired := make(chan string, 2)
+	errs := make(chan error, 2)
+	background := newPositionRecorder()
+	interactive := newPositionRecorder()
+
+	acquireAsync(WithQueueObserver(context.Background(), background.observe), b, "background", acquired, errs)
+	background.waitFor(t, 1)
+
+	// The interactive caller jumps the queue, pushing the background one back
+	ctx := WithQueueObserver(WithPriority(context.Background(), PriorityInteractive), interactive.observe)
+	acquireAsync(ctx, b, "interactive", acquired, errs)
+	interactive.waitFor(t, 1)
+	background.waitFor(t, 2)
+
+	b.release()
+	receive(t, acquired)
+	background.waitFor(t, 1)
+
+	b.release()
+	receive(t, acquired)
+
+	background.mu.Lock()
+	defer background.mu.Unlock()
+	if want := []int{1, 2, 1}; !slices.Equal(background.positions, want) {
+		t.Errorf("background caller was told %v, want %v", background.positions, want)
+	}
+}
+
+func TestAcquireWaitsForRunningObserver(t *testing.T) {
+	b := newTestBudget(t, 1)
+	if err := b.acquire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	observing := make(chan struct{})
+	finish := make(chan struct{})
+	observer := func(int) {
+		close(observing)
+		<-finish
+	}
+
+	acquired := make(chan string, 1)
+	errs := make(chan error, 1)
+	acquireAsync(WithQueueObserver(context.Background(), observer), b, "observed", acquired, errs)
+	receive(t, observing)
+
+	b.release()
+	select {
+	case <-acquired:
+		t.Fatal("acquire() returned while its observer was still running")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(finish)
+	receive(t, acquired)
+}
+
+func TestSharedCallIsPromoted(t *testing.T) {
+	b := newTestBudget(t, 1)
+	if err := b.acquire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	acquired := make(chan string, 2)
+	errs := make(chan error, 2)
+	acquireAsync(context.Background(), b, "background", acquired, errs)
+	waitQueued(t, b, 1)
+
+	shared := NewShared()
+	leaveBackground := shared.Join(context.Background())
+	defer leaveBackground()
+	acquireAsync(WithShared(context.Background(), shared), b, "shared", acquired, errs)
+	waitQueued(t, b, 2)
+
+	// An interactive caller joining the shared call moves it ahead of the background caller
+	joiner := newPositionRecorder()
+	leaveInteractive := shared.Join(WithQueueObserver(WithPriority(context.Background(), PriorityInteractive), joiner.observe))
+	defer leaveInteractive()
+	joiner.waitFor(t, 1)
+
+	b.release()
+	if got := receive(t, acquired); got != "shared" {
+		t.Fatalf("slot went to %s, want the promoted shared call", got)
+	}
+}
+
+func TestSharedCallReportsToLateJoiner(t *testing.T) {
+	b := newTestBudget(t, 1)
+	if err := b.acquire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	acquired := make(chan string, 2)
+	errs := make(chan error, 2)
+	acquireAsync(context.Background(), b, "background", acquired, errs)
+	waitQueued(t, b, 1)
+
+	shared := NewShared()
+	acquireAsync(WithShared(context.Background(), shared), b, "shared", acquired, errs)
+	waitQueued(t, b, 2)
+
+	// Joined after the call was queued, at the same priority, so it only learns the position
+	late := newPositionRecorder()
+	deadline := time.Now().Add(time.Second)
+	for {
+		shared.mu.Lock()
+		position := shared.lastPosition
+		shared.mu.Unlock()
+		if position == 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("shared call never learned its position")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	leave := shared.Join(WithQueueObserver(context.Background(), late.observe))
+	late.waitFor(t, 2)
+
+	b.release()
+	receive(t, acquired)
+	late.waitFor(t, 1)
+
+	leave()
+	b.release()
+	receive(t, acquired)
+}
@@ -0,0 +1,45 @@
+package budget
+
+import "context"
+
+// Priority orders the callers waiting for a scraper slot.
+type Priority int
+
+const (
+	// PriorityBackground is used by scheduled checks and is the default.
+	PriorityBackground Priority = iota
+	// PriorityInteractive is used by user commands, which are served before any background call.
+	PriorityInteractive
+)
+
+// QueueObserver is told the caller's 1-based position whenever it changes while the caller
+// waits for a slot. It runs on its own goroutine, so a slow observer only delays its own
+// updates; positions it has not caught up with are skipped. Its last call returns before the
+// scraper call starts or gives up.
+type QueueObserver func(position int)
+
+type priorityKey struct{}
+
+type observerKey struct{}
+
+// WithPriority returns a context whose scraper calls wait with the given priority.
+func WithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+// WithQueueObserver returns a context whose scraper calls report their queue position to observer.
+func WithQueueObserver(ctx context.Context, observer QueueObserver) context.Context {
+	return context.WithValue(ctx, observerKey{}, observer)
+}
+
+func priorityFrom(ctx context.Context) Priority {
+	if priority, ok := ctx.Value(priorityKey{}).(Priority); ok {
+		return priority
+	}
+	return PriorityBackground
+}
+
+func observerFrom(ctx context.Context) QueueObserver {
+	observer, _ := ctx.Value(observerKey{}).(QueueObserver)
+	return observer
+}
@@ -0,0 +1,108 @@
+package budget
+
+import (
+	"context"
+	"sync"
+)
+
+// Shared is a call made on behalf of several callers, such as identical calls merged by a
+// cache. It waits with the highest priority among its callers and reports its queue position
+// to all of their observers, including callers that join while it is already queued.
+type Shared struct {
+	mu           sync.Mutex
+	priority     Priority
+	observers    map[int]QueueObserver
+	nextID       int
+	lastPosition int
+	promote      func() // Set by the budget while the call is queued at background priority
+
+	reporting sync.Mutex // Held while observers run, so a leaving caller waits for a report
+}
+
+type sharedKey struct{}
+
+// NewShared returns a shared call with no callers yet.
+func NewShared() *Shared {
+	return &Shared{observers: make(map[int]QueueObserver)}
+}
+
+// WithShared returns a context whose scraper calls wait on behalf of the callers of shared,
+// instead of with the priority and observer of ctx.
+func WithShared(ctx context.Context, shared *Shared) context.Context {
+	return context.WithValue(ctx, sharedKey{}, shared)
+}
+
+func sharedFrom(ctx context.Context) *Shared {
+	shared, _ := ctx.Value(sharedKey{}).(*Shared)
+	return shared
+}
+
+// Join adds the priority and observer of the caller's ctx to the call. The returned function
+// removes the observer again, for a caller that stops waiting; once it returns, the observer
+// is not called anymore.
+func (s *Shared) Join(ctx context.Context) func() {
+	s.mu.Lock()
+	var promote func()
+	if priorityFrom(ctx) > s.priority {
+		s.priority = priorityFrom(ctx)
+		promote, s.promote = s.promote, nil
+	}
+
+	id := s.nextID
+	s.nextID++
+	observer := observerFrom(ctx)
+	if observer != nil {
+		s.observers[id] = observer
+	}
+	position := s.lastPosition
+	s.mu.Unlock()
+
+	// A promotion reports the new position to every observer. Otherwise a caller joining a
+	// queued call learns its position without waiting for the next change; the caller would
+	// only be waiting anyway, so it runs the observer itself.
+	if promote != nil {
+		promote()
+	} else if observer != nil && position > 0 {
+		observer(position)
+	}
+
+	return func() {
+		s.mu.Lock()
+		delete(s.observers, id)
+		s.mu.Unlock()
+
+		// Wait for a report that may already have picked up the observer
+		s.reporting.Lock()
+		s.reporting.Unlock()
+	}
+}
+
+// watch returns the call's priority and, while it is below interactive, remembers promote to
+// be called once a caller raises it. A nil promote stops watching.
+func (s *Shared) watch(promote func()) Priority {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.promote = nil
+	if promote != nil && s.priority < PriorityInteractive {
+		s.promote = promote
+	}
+	return s.priority
+}
+
+// observe is the queue observer of the call, passing the position on to every caller.
+func (s *Shared) observe(position int) {
+	s.reporting.Lock()
+	defer s.reporting.Unlock()
+
+	s.mu.Lock()
+	s.lastPosition = position
+	observers := make([]QueueObserver, 0, len(s.observers))
+	for _, observer := range s.observers {
+		observers = append(observers, observer)
+	}
+	s.mu.Unlock()
+
+	for _, observer := range observers {
+		observer(position)
+	}
+}
@@ -0,0 +1,147 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram/budget"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/logger"
+)
+
+type refreshKey struct{}
+
+// WithRefresh returns a context whose scraper calls skip cached results. The fresh result is
+// cached for later callers, and a call that is already in flight is still joined.
+func WithRefresh(ctx context.Context) context.Context {
+	return context.WithValue(ctx, refreshKey{}, true)
+}
+
+func isRefresh(ctx context.Context) bool {
+	refresh, _ := ctx.Value(refreshKey{}).(bool)
+	return refresh
+}
+
+// ttls are how long the results of each kind of call are kept. Zero disables caching for that
+// kind; identical concurrent calls are merged regardless.
+type ttls struct {
+	stories    time.Duration
+	posts      time.Duration // GetUserPosts and GetUserReels
+	highlights time.Duration // GetHighlightAlbumPreviews and GetSingleHighlightAlbum
+	profile    time.Duration
+	media      time.Duration // GetUserPost and GetUserReel
+	snapshot   time.Duration
+}
+
+type entry struct {
+	value   any
+	expires time.Time
+}
+
+// flight is a call in progress that identical calls wait on. It is cancelled once every
+// caller waiting on it has given up.
+type flight struct {
+	done    chan struct{}
+	value   any
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+	shared  *budget.Shared
+}
+
+// store merges identical calls and keeps their results until they expire.
+type store struct {
+	logger logger.Logger
+
+	mu      sync.Mutex
+	entries map[string]entry
+	flights map[string]*flight
+}
+
+func newStore(logger logger.Logger) *store {
+	return &store{
+		logger:  logger,
+		entries: make(map[string]entry),
+		flights: make(map[string]*flight),
+	}
+}
+
+// do returns the cached result for key, joins the call in flight for it, or starts fn. fn runs
+// with the first caller's context values but is only cancelled once no caller waits for it.
+// While it waits for a scraper slot it has the highest priority among its callers and reports
+// the queue position to all of them.
+func (s *store) do(ctx context.Context, key string, ttl time.Duration, fn func(ctx context.Context) (any, error)) (any, error) {
+	s.mu.Lock()
+	if !isRefresh(ctx) {
+		if e, ok := s.entries[key]; ok && time.Now().Before(e.expires) {
+			s.mu.Unlock()
+			s.logger.Debug("Serving scrape result from cache", "key", key)
+			return e.value, nil
+		}
+	}
+
+	f, ok := s.flights[key]
+	if ok {
+		s.logger.Debug("Joining scrape already in flight", "key", key)
+	} else {
+		shared := budget.NewShared()
+		flightCtx, cancel := context.WithCancel(budget.WithShared(context.WithoutCancel(ctx), shared))
+		f = &flight{done: make(chan struct{}), cancel: cancel, shared: shared}
+		s.flights[key] = f
+		go s.run(flightCtx, key, ttl, f, fn)
+	}
+	f.waiters++
+	s.mu.Unlock()
+
+	leave := f.shared.Join(ctx)
+	defer leave()
+
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		s.mu.Lock()
+		f.waiters--
+		if f.waiters == 0 {
+			f.cancel()
+		}
+		s.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+func (s *store) run(ctx context.Context, key string, ttl time.Duration, f *flight, fn func(ctx context.Context) (any, error)) {
+	value, err := fn(ctx)
+
+	s.mu.Lock()
+	delete(s.flights, key)
+	if err == nil && ttl > 0 {
+		s.setLocked(key, value, ttl)
+	}
+	f.value, f.err = value, err
+	close(f.done)
+	s.mu.Unlock()
+
+	f.cancel()
+}
+
+// set caches value under key, for results learned from another call.
+func (s *store) set(key string, value any, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+	s.mu.Lock()
+	s.setLocked(key, value, ttl)
+	s.mu.Unlock()
+}
+
+// setLocked stores an entry and drops the expired ones. s.mu must be held.
+func (s *store) setLocked(key string, value any, ttl time.Duration) {
+	now := time.Now()
+	for k, e := range s.entries {
+		if !now.Before(e.expires) {
+			delete(s.entries, k)
+		}
+	}
+	s.entries[key] = entry{value: value, expires: now.Add(ttl)}
+}
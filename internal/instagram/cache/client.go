@@ -0,0 +1,212 @@
+package cache
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/config"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/logger"
+)
+
+// Client is an instagram.Client that merges identical concurrent calls into one and keeps
+// their results for a short while. Callers get their own copy of each result, since several
+// of them mutate what they receive.
+type Client struct {
+	next  instagram.Client
+	ttls  ttls
+	store *store
+}
+
+var _ instagram.Client = (*Client)(nil)
+
+// New wraps client in a cache with the TTLs of the parser config.
+func New(client instagram.Client, cfg *config.Config, logger logger.Logger) instagram.Client {
+	return &Client{
+		next: client,
+		ttls: ttls{
+			stories:    cfg.Parser.CacheStoriesTTL,
+			posts:      cfg.Parser.CachePostsTTL,
+			highlights: cfg.Parser.CacheHighlightsTTL,
+			profile:    cfg.Parser.CacheProfileTTL,
+			media:      cfg.Parser.CacheMediaTTL,
+			snapshot:   cfg.Parser.CacheSnapshotTTL,
+		},
+		store: newStore(logger.WithComponent("ScrapeCache")),
+	}
+}
+
+func key(method string, args ...string) string {
+	return method + "\x00" + strings.Join(args, "\x00")
+}
+
+// cached runs fn through the store and returns a copy of the shared result.
+func cached[T any](ctx context.Context, c *Client, key string, ttl time.Duration, fn func(ctx context.Context) (T, error), clone func(T) T) (T, error) {
+	// A call pinned to one provider checks that provider, so it must not be answered by a
+	// result another provider served
+	if instagram.ProviderFrom(ctx) != "" {
+		return fn(ctx)
+	}
+
+	value, err := c.store.do(ctx, key, ttl, func(ctx context.Context) (any, error) {
+		return fn(ctx)
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return clone(value.(T)), nil
+}
+
+// The clones copy every slice a caller could modify, down to the renditions of each media
+// item, so no caller shares memory with another caller or with the cached value.
+
+func cloneSlice[T any](s []T) []T {
+	if s == nil {
+		return nil
+	}
+	return append(make([]T, 0, len(s)), s...)
+}
+
+func cloneEach[T any](s []T, clone func(T) T) []T {
+	s = cloneSlice(s)
+	for i := range s {
+		s[i] = clone(s[i])
+	}
+	return s
+}
+
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+func cloneMedia(media domain.MediaItem) domain.MediaItem {
+	media.Resolutions = cloneSlice(media.Resolutions)
+	return media
+}
+
+func cloneStory(story domain.StoryItem) domain.StoryItem {
+	story.Media = cloneMedia(story.Media)
+	return story
+}
+
+func clonePost(post domain.PostItem) domain.PostItem {
+	post.Media = cloneEach(post.Media, cloneMedia)
+	return post
+}
+
+func cloneStories(stories []domain.StoryItem) []domain.StoryItem {
+	return cloneEach(stories, cloneStory)
+}
+
+func clonePosts(posts []domain.PostItem) []domain.PostItem {
+	return cloneEach(posts, clonePost)
+}
+
+func clonePostPtr(post *domain.PostItem) *domain.PostItem {
+	if post == nil {
+		return nil
+	}
+	clone := clonePost(*post)
+	return &clone
+}
+
+func cloneHighlightReel(reel *domain.HighlightReel) *domain.HighlightReel {
+	clone := clonePtr(reel)
+	if clone != nil {
+		clone.Items = cloneStories(clone.Items)
+	}
+	return clone
+}
+
+func cloneSnapshot(snapshot *domain.AccountSnapshot) *domain.AccountSnapshot {
+	clone := clonePtr(snapshot)
+	if clone != nil {
+		clone.Stories = cloneStories(clone.Stories)
+		clone.Posts = clonePosts(clone.Posts)
+		clone.Highlights = cloneSlice(clone.Highlights)
+	}
+	return clone
+}
+
+func (c *Client) GetUserStories(ctx context.Context, userName string) ([]domain.StoryItem, error) {
+	return cached(ctx, c, key("GetUserStories", userName), c.ttls.stories, func(ctx context.Context) ([]domain.StoryItem, error) {
+		return c.next.GetUserStories(ctx, userName)
+	}, cloneStories)
+}
+
+// GetUserHighlights is passed through, since its results are consumed by the callback as the
+// albums are walked.
+func (c *Client) GetUserHighlights(ctx context.Context, userName string, processorFunc instagram.HighlightReelProcessorFunc) error {
+	return c.next.GetUserHighlights(ctx, userName, processorFunc)
+}
+
+func (c *Client) GetHighlightAlbumPreviews(ctx context.Context, userName string) ([]domain.HighlightAlbumPreview, error) {
+	return cached(ctx, c, key("GetHighlightAlbumPreviews", userName), c.ttls.highlights, func(ctx context.Context) ([]domain.HighlightAlbumPreview, error) {
+		return c.next.GetHighlightAlbumPreviews(ctx, userName)
+	}, cloneSlice[domain.HighlightAlbumPreview])
+}
+
+func (c *Client) GetSingleHighlightAlbum(ctx context.Context, userName, albumID string) (*domain.HighlightReel, error) {
+	return cached(ctx, c, key("GetSingleHighlightAlbum", userName, albumID), c.ttls.highlights, func(ctx context.Context) (*domain.HighlightReel, error) {
+		return c.next.GetSingleHighlightAlbum(ctx, userName, albumID)
+	}, cloneHighlightReel)
+}
+
+func (c *Client) GetUserPost(ctx context.Context, postURL string) (*domain.PostItem, error) {
+	return cached(ctx, c, key("GetUserPost", postURL), c.ttls.media, func(ctx context.Context) (*domain.PostItem, error) {
+		return c.next.GetUserPost(ctx, postURL)
+	}, clonePostPtr)
+}
+
+func (c *Client) GetUserReel(ctx context.Context, reelURL string) (*domain.PostItem, error) {
+	return cached(ctx, c, key("GetUserReel", reelURL), c.ttls.media, func(ctx context.Context) (*domain.PostItem, error) {
+		return c.next.GetUserReel(ctx, reelURL)
+	}, clonePostPtr)
+}
+
+func (c *Client) GetUserPosts(ctx context.Context, userName string) ([]domain.PostItem, error) {
+	return cached(ctx, c, key("GetUserPosts", userName), c.ttls.posts, func(ctx context.Context) ([]domain.PostItem, error) {
+		return c.next.GetUserPosts(ctx, userName)
+	}, clonePosts)
+}
+
+func (c *Client) GetUserReels(ctx context.Context, userName string) ([]domain.PostItem, error) {
+	return cached(ctx, c, key("GetUserReels", userName), c.ttls.posts, func(ctx context.Context) ([]domain.PostItem, error) {
+		return c.next.GetUserReels(ctx, userName)
+	}, clonePosts)
+}
+
+func (c *Client) GetUserProfile(ctx context.Context, userName string) (*domain.UserProfile, error) {
+	return cached(ctx, c, key("GetUserProfile", userName), c.ttls.profile, func(ctx context.Context) (*domain.UserProfile, error) {
+		return c.next.GetUserProfile(ctx, userName)
+	}, clonePtr[domain.UserProfile])
+}
+
+// GetProfileSnapshot also caches the parts of a fresh snapshot as the results of the matching
+// single-content calls.
+func (c *Client) GetProfileSnapshot(ctx context.Context, userName string) (*domain.AccountSnapshot, error) {
+	return cached(ctx, c, key("GetProfileSnapshot", userName), c.ttls.snapshot, func(ctx context.Context) (*domain.AccountSnapshot, error) {
+		snapshot, err := c.next.GetProfileSnapshot(ctx, userName)
+		if err != nil {
+			return nil, err
+		}
+
+		profile := snapshot.Profile
+		c.store.set(key("GetUserProfile", userName), &profile, c.ttls.profile)
+		if !profile.IsPrivate {
+			c.store.set(key("GetUserStories", userName), snapshot.Stories, c.ttls.stories)
+			c.store.set(key("GetUserPosts", userName), snapshot.Posts, c.ttls.posts)
+		}
+		if snapshot.Highlights != nil {
+			c.store.set(key("GetHighlightAlbumPreviews", userName), snapshot.Highlights, c.ttls.highlights)
+		}
+		return snapshot, nil
+	}, cloneSnapshot)
+}
@@ -0,0 +1,300 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram/budget"
+	mock_instagram "github.com/orgball2608/insta-parser-telegram-bot/internal/instagram/mocks"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/config"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/logger"
+	"go.uber.org/mock/gomock"
+)
+
+var testStories = []domain.StoryItem{{
+	ID:       "1",
+	Username: "someone",
+	Media: domain.MediaItem{
+		Type:        domain.MediaTypeVideo,
+		URL:         "https://cdn.example.com/1_720.mp4",
+		Resolutions: []domain.MediaResolution{{URL: "https://cdn.example.com/1_720.mp4", Width: 720}},
+	},
+}}
+
+func testConfig(ttl time.Duration) *config.Config {
+	return &config.Config{Parser: config.ParserConfig{
+		CacheStoriesTTL:   ttl,
+		CachePostsTTL:     ttl,
+		CacheProfileTTL:   ttl,
+		ScrapeConcurrency: 1,
+	}}
+}
+
+func newTestClient(t *testing.T, ttl time.Duration) (*Client, *mock_instagram.MockClient) {
+	t.Helper()
+	next := mock_instagram.NewMockClient(gomock.NewController(t))
+	return New(next, testConfig(ttl), logger.New(logger.Opts{Env: "test"})).(*Client), next
+}
+
+// waitWaiters waits until the call in flight for key has n callers.
+func waitWaiters(t *testing.T, c *Client, key string, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for {
+		c.store.mu.Lock()
+		waiters := 0
+		if f, ok := c.store.flights[key]; ok {
+			waiters = f.waiters
+		}
+		c.store.mu.Unlock()
+		if waiters == n {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d callers wait for %q, want %d", waiters, key, n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func receive[T any](t *testing.T, ch <-chan T) T {
+	t.Helper()
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(time.Second):
+		t.Fatal("timed out")
+		panic("unreachable")
+	}
+}
+
+type storiesResult struct {
+	stories []domain.StoryItem
+	err     error
+}
+
+func getStoriesAsync(ctx context.Context, c *Client) <-chan storiesResult {
+	results := make(chan storiesResult, 1)
+	go func() {
+		stories, err := c.GetUserStories(ctx, "someone")
+		results <- storiesResult{stories, err}
+	}()
+	return results
+}
+
+func TestIdenticalCallsAreMerged(t *testing.T) {
+	c, next := newTestClient(t, 0)
+
+	release := make(chan struct{})
+	next.EXPECT().GetUserStories(gomock.Any(), "someone").DoAndReturn(func(context.Context, string) ([]domain.StoryItem, error) {
+		<-release
+		return testStories, nil
+	}).Times(1)
+
+	first := getStoriesAsync(context.Background(), c)
+	second := getStoriesAsync(context.Background(), c)
+	waitWaiters(t, c, key("GetUserStories", "someone"), 2)
+	close(release)
+
+	for _, results := range []<-chan storiesResult{first, second} {
+		result := receive(t, results)
+		if result.err != nil || len(result.stories) != 1 {
+			t.Errorf("GetUserStories() = %v, %v, want the merged result", result.stories, result.err)
+		}
+	}
+}
+
+func TestResultsAreCopied(t *testing.T) {
+	c, next := newTestClient(t, time.Hour)
+
+	next.EXPECT().GetUserStories(gomock.Any(), "someone").Return(testStories, nil).Times(1)
+
+	first, err := c.GetUserStories(context.Background(), "someone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	first[0].ID = "changed"
+	first[0].Media.Type = domain.MediaTypeImage
+	first[0].Media.Resolutions[0].URL = "changed"
+
+	second, err := c.GetUserStories(context.Background(), "someone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second[0].ID != "1" || !second[0].Media.IsVideo() || second[0].Media.Resolutions[0].URL != "https://cdn.example.com/1_720.mp4" {
+		t.Errorf("cached result was changed through another caller's copy: %+v", second[0])
+	}
+}
+
+func TestCachedResultsExpire(t *testing.T) {
+	c, next := newTestClient(t, 20*time.Millisecond)
+
+	next.EXPECT().GetUserStories(gomock.Any(), "someone").Return(testStories, nil).Times(2)
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetUserStories(context.Background(), "someone"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	time.Sleep(30 * time.Millisecond)
+	if _, err := c.GetUserStories(context.Background(), "someone"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestErrorsAreNotCached(t *testing.T) {
+	c, next := newTestClient(t, time.Hour)
+
+	gomock.InOrder(
+		next.EXPECT().GetUserStories(gomock.Any(), "someone").Return(nil, instagram.ErrTimeout),
+		next.EXPECT().GetUserStories(gomock.Any(), "someone").Return(testStories, nil),
+	)
+
+	if _, err := c.GetUserStories(context.Background(), "someone"); !errors.Is(err, instagram.ErrTimeout) {
+		t.Fatalf("GetUserStories() error = %v, want %v", err, instagram.ErrTimeout)
+	}
+	if _, err := c.GetUserStories(context.Background(), "someone"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRefreshSkipsCachedResult(t *testing.T) {
+	c, next := newTestClient(t, time.Hour)
+
+	fresh := []domain.StoryItem{{ID: "2", Username: "someone"}}
+	gomock.InOrder(
+		next.EXPECT().GetUserStories(gomock.Any(), "someone").Return(testStories, nil),
+		next.EXPECT().GetUserStories(gomock.Any(), "someone").Return(fresh, nil),
+	)
+
+	if _, err := c.GetUserStories(context.Background(), "someone"); err != nil {
+		t.Fatal(err)
+	}
+	stories, err := c.GetUserStories(WithRefresh(context.Background()), "someone")
+	if err != nil || stories[0].ID != "2" {
+		t.Fatalf("GetUserStories() with refresh = %v, %v, want the fresh result", stories, err)
+	}
+
+	// The fresh result replaces the cached one
+	stories, err = c.GetUserStories(context.Background(), "someone")
+	if err != nil || stories[0].ID != "2" {
+		t.Fatalf("GetUserStories() = %v, %v, want the refreshed result", stories, err)
+	}
+}
+
+func TestPinnedProviderSkipsCache(t *testing.T) {
+	c, next := newTestClient(t, time.Hour)
+
+	next.EXPECT().GetUserStories(gomock.Any(), "someone").Return(testStories, nil).Times(2)
+
+	if _, err := c.GetUserStories(context.Background(), "someone"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetUserStories(instagram.WithProvider(context.Background(), "http"), "someone"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCallIsCancelledWhenLastCallerLeaves(t *testing.T) {
+	c, next := newTestClient(t, 0)
+
+	started := make(chan struct{})
+	cancelled := make(chan struct{})
+	next.EXPECT().GetUserStories(gomock.Any(), "someone").DoAndReturn(func(ctx context.Context, _ string) ([]domain.StoryItem, error) {
+		close(started)
+		<-ctx.Done()
+		close(cancelled)
+		return nil, ctx.Err()
+	})
+
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	secondCtx, cancelSecond := context.WithCancel(context.Background())
+	first := getStoriesAsync(firstCtx, c)
+	second := getStoriesAsync(secondCtx, c)
+	waitWaiters(t, c, key("GetUserStories", "someone"), 2)
+	receive(t, started)
+
+	cancelFirst()
+	if result := receive(t, first); !errors.Is(result.err, context.Canceled) {
+		t.Fatalf("GetUserStories() error = %v, want %v", result.err, context.Canceled)
+	}
+	select {
+	case <-cancelled:
+		t.Fatal("call was cancelled while a caller still waited for it")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	cancelSecond()
+	receive(t, second)
+	receive(t, cancelled)
+}
+
+func TestMergedCallTakesHighestCallerPriority(t *testing.T) {
+	next := mock_instagram.NewMockClient(gomock.NewController(t))
+	log := logger.New(logger.Opts{Env: "test"})
+	c := New(budget.New(next, testConfig(0), log), testConfig(0), log).(*Client)
+
+	// The only slot is held by a profile call until holding is closed
+	held, holding := make(chan struct{}), make(chan struct{})
+	order := make(chan string, 2)
+	next.EXPECT().GetUserProfile(gomock.Any(), "holder").DoAndReturn(func(context.Context, string) (*domain.UserProfile, error) {
+		close(held)
+		<-holding
+		return &domain.UserProfile{}, nil
+	})
+	next.EXPECT().GetUserPosts(gomock.Any(), "someone").DoAndReturn(func(context.Context, string) ([]domain.PostItem, error) {
+		order <- "posts"
+		return nil, nil
+	})
+	next.EXPECT().GetUserStories(gomock.Any(), "someone").DoAndReturn(func(context.Context, string) ([]domain.StoryItem, error) {
+		order <- "stories"
+		return testStories, nil
+	})
+
+	go c.GetUserProfile(context.Background(), "holder")
+	receive(t, held)
+
+	positions := func() (budget.QueueObserver, chan int) {
+		ch := make(chan int, 10)
+		return func(position int) { ch <- position }, ch
+	}
+
+	postsObserver, postsPositions := positions()
+	go c.GetUserPosts(budget.WithQueueObserver(context.Background(), postsObserver), "someone")
+	if got := receive(t, postsPositions); got != 1 {
+		t.Fatalf("posts call queued at %d, want 1", got)
+	}
+
+	storiesObserver, storiesPositions := positions()
+	background := getStoriesAsync(budget.WithQueueObserver(context.Background(), storiesObserver), c)
+	if got := receive(t, storiesPositions); got != 2 {
+		t.Fatalf("stories call queued at %d, want 2", got)
+	}
+
+	// A user command asking for the same stories moves the merged call ahead of the posts call
+	interactiveObserver, interactivePositions := positions()
+	interactiveCtx := budget.WithQueueObserver(budget.WithPriority(context.Background(), budget.PriorityInteractive), interactiveObserver)
+	interactive := getStoriesAsync(interactiveCtx, c)
+	if got := receive(t, interactivePositions); got != 1 {
+		t.Fatalf("interactive caller was told position %d, want 1", got)
+	}
+	if got := receive(t, storiesPositions); got != 1 {
+		t.Fatalf("background caller of the merged call was told position %d, want 1", got)
+	}
+
+	close(holding)
+	if got := receive(t, order); got != "stories" {
+		t.Fatalf("%s call ran first, want the promoted stories call", got)
+	}
+	receive(t, order)
+
+	for _, results := range []<-chan storiesResult{background, interactive} {
+		if result := receive(t, results); result.err != nil {
+			t.Errorf("GetUserStories() error = %v", result.err)
+		}
+	}
+}
@@ -0,0 +1,381 @@
+package composite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/config"
+	apperrors "github.com/orgball2608/insta-parser-telegram-bot/pkg/errors"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/logger"
+	"go.uber.org/fx"
+)
+
+var (
+	// ErrNoProviders is returned when no provider is configured or every provider failed.
+	ErrNoProviders = fmt.Errorf("no instagram provider available: %w", instagram.ErrProviderDown)
+	// ErrProcessorFailed wraps an error of the caller's highlight processor. It says nothing
+	// about the provider, and the albums already processed must not be walked again on the next
+	// provider, so it is returned as is.
+	ErrProcessorFailed = errors.New("highlight processor failed")
+)
+
+type Opts struct {
+	fx.In
+
+	Config    *config.Config
+	Logger    logger.Logger
+	Providers []instagram.Provider `group:"instagram_providers"`
+}
+
+// providerState tracks the health of a single provider.
+type providerState struct {
+	provider instagram.Provider
+	priority int
+
+	mu                  sync.Mutex
+	consecutiveFailures int
+	lastError           string
+	lastFailureAt       time.Time
+	lastSuccessAt       time.Time
+	cooldownUntil       time.Time
+}
+
+// Composite is an instagram.Client that tries the configured providers in priority order,
+// skipping providers that are cooling down after repeated failures.
+type Composite struct {
+	providers        []*providerState
+	logger           logger.Logger
+	cooldown         time.Duration
+	failureThreshold int
+}
+
+var (
+	_ instagram.Client         = (*Composite)(nil)
+	_ instagram.HealthReporter = (*Composite)(nil)
+)
+
+func New(opts Opts) (*Composite, error) {
+	registry := make(map[string]instagram.Provider, len(opts.Providers))
+	for _, p := range opts.Providers {
+		if _, exists := registry[p.Name]; exists {
+			return nil, fmt.Errorf("instagram provider %q registered twice", p.Name)
+		}
+		registry[p.Name] = p
+	}
+
+	names := opts.Config.Parser.Providers
+	if len(names) == 0 {
+		for _, p := range opts.Providers {
+			names = append(names, p.Name)
+		}
+	}
+
+	var providers []*providerState
+	for i, name := range names {
+		p, ok := registry[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown instagram provider %q", name)
+		}
+		providers = append(providers, &providerState{provider: p, priority: i + 1})
+	}
+
+	if len(providers) == 0 {
+		return nil, ErrNoProviders
+	}
+
+	threshold := opts.Config.Parser.ProviderFailureThreshold
+	if threshold < 1 {
+		threshold = 1
+	}
+
+	log := opts.Logger.WithComponent("InstagramProviders")
+	log.Info("Instagram providers configured", "providers", names)
+
+	return &Composite{
+		providers:        providers,
+		logger:           log,
+		cooldown:         opts.Config.Parser.ProviderCooldown,
+		failureThreshold: threshold,
+	}, nil
+}
+
+func (c *Composite) GetUserStories(ctx context.Context, userName string) ([]domain.StoryItem, error) {
+	stories, provider, err := call(ctx, c, "GetUserStories", func(client instagram.Client) ([]domain.StoryItem, error) {
+		return client.GetUserStories(ctx, userName)
+	})
+	for i := range stories {
+		stories[i].Provider = provider
+	}
+	return stories, err
+}
+
+func (c *Composite) GetUserHighlights(ctx context.Context, userName string, processorFunc instagram.HighlightReelProcessorFunc) error {
+	processor := func(reel domain.HighlightReel) error {
+		if err := processorFunc(reel); err != nil {
+			return fmt.Errorf("%w: %w", ErrProcessorFailed, err)
+		}
+		return nil
+	}
+
+	_, _, err := call(ctx, c, "GetUserHighlights", func(client instagram.Client) (struct{}, error) {
+		return struct{}{}, client.GetUserHighlights(ctx, userName, processor)
+	})
+	return err
+}
+
+func (c *Composite) GetHighlightAlbumPreviews(ctx context.Context, userName string) ([]domain.HighlightAlbumPreview, error) {
+	previews, provider, err := call(ctx, c, "GetHighlightAlbumPreviews", func(client instagram.Client) ([]domain.HighlightAlbumPreview, error) {
+		return client.GetHighlightAlbumPreviews(ctx, userName)
+	})
+	for i := range previews {
+		previews[i].Provider = provider
+	}
+	return previews, err
+}
+
+func (c *Composite) GetSingleHighlightAlbum(ctx context.Context, userName, albumID string) (*domain.HighlightReel, error) {
+	reel, provider, err := call(ctx, c, "GetSingleHighlightAlbum", func(client instagram.Client) (*domain.HighlightReel, error) {
+		return client.GetSingleHighlightAlbum(ctx, userName, albumID)
+	})
+	if reel != nil {
+		reel.Provider = provider
+		for i := range reel.Items {
+			reel.Items[i].Provider = provider
+		}
+	}
+	return reel, err
+}
+
+func (c *Composite) GetUserPost(ctx context.Context, postURL string) (*domain.PostItem, error) {
+	post, provider, err := call(ctx, c, "GetUserPost", func(client instagram.Client) (*domain.PostItem, error) {
+		return client.GetUserPost(ctx, postURL)
+	})
+	if post != nil {
+		post.Provider = provider
+	}
+	return post, err
+}
+
+func (c *Composite) GetUserReel(ctx context.Context, reelURL string) (*domain.PostItem, error) {
+	reel, provider, err := call(ctx, c, "GetUserReel", func(client instagram.Client) (*domain.PostItem, error) {
+		return client.GetUserReel(ctx, reelURL)
+	})
+	if reel != nil {
+		reel.Provider = provider
+	}
+	return reel, err
+}
+
+func (c *Composite) GetUserPosts(ctx context.Context, userName string) ([]domain.PostItem, error) {
+	posts, provider, err := call(ctx, c, "GetUserPosts", func(client instagram.Client) ([]domain.PostItem, error) {
+		return client.GetUserPosts(ctx, userName)
+	})
+	for i := range posts {
+		posts[i].Provider = provider
+	}
+	return posts, err
+}
+
+func (c *Composite) GetUserReels(ctx context.Context, userName string) ([]domain.PostItem, error) {
+	reels, provider, err := call(ctx, c, "GetUserReels", func(client instagram.Client) ([]domain.PostItem, error) {
+		return client.GetUserReels(ctx, userName)
+	})
+	for i := range reels {
+		reels[i].Provider = provider
+	}
+	return reels, err
+}
+
+func (c *Composite) GetUserProfile(ctx context.Context, userName string) (*domain.UserProfile, error) {
+	profile, provider, err := call(ctx, c, "GetUserProfile", func(client instagram.Client) (*domain.UserProfile, error) {
+		return client.GetUserProfile(ctx, userName)
+	})
+	if profile != nil {
+		profile.Provider = provider
+	}
+	return profile, err
+}
+
+func (c *Composite) GetProfileSnapshot(ctx context.Context, userName string) (*domain.AccountSnapshot, error) {
+	snapshot, provider, err := call(ctx, c, "GetProfileSnapshot", func(client instagram.Client) (*domain.AccountSnapshot, error) {
+		return client.GetProfileSnapshot(ctx, userName)
+	})
+	if snapshot != nil {
+		snapshot.Provider = provider
+		snapshot.Profile.Provider = provider
+		for i := range snapshot.Stories {
+			snapshot.Stories[i].Provider = provider
+		}
+		for i := range snapshot.Posts {
+			snapshot.Posts[i].Provider = provider
+		}
+		for i := range snapshot.Highlights {
+			snapshot.Highlights[i].Provider = provider
+		}
+	}
+	return snapshot, err
+}
+
+// ProviderStatuses returns the health of every configured provider in priority order.
+func (c *Composite) ProviderStatuses() []instagram.ProviderStatus {
+	now := time.Now()
+	statuses := make([]instagram.ProviderStatus, 0, len(c.providers))
+	for _, p := range c.providers {
+		p.mu.Lock()
+		statuses = append(statuses, instagram.ProviderStatus{
+			Name:                p.provider.Name,
+			Priority:            p.priority,
+			Healthy:             !now.Before(p.cooldownUntil),
+			ConsecutiveFailures: p.consecutiveFailures,
+			LastError:           p.lastError,
+			LastFailureAt:       p.lastFailureAt,
+			LastSuccessAt:       p.lastSuccessAt,
+			CooldownUntil:       p.cooldownUntil,
+		})
+		p.mu.Unlock()
+	}
+	return statuses
+}
+
+// call runs fn against each available provider in turn and returns the first successful result
+// together with the name of the provider that served it.
+func call[T any](ctx context.Context, c *Composite, method string, fn func(instagram.Client) (T, error)) (T, string, error) {
+	var zero T
+	var errs []error
+
+	for _, p := range c.candidates(ctx) {
+		if err := ctx.Err(); err != nil {
+			return zero, "", err
+		}
+
+		name := p.provider.Name
+		result, err := fn(p.provider.Client)
+		if err == nil {
+			p.recordSuccess()
+			c.logger.Info("Request served by provider", "method", method, "provider", name)
+			return result, name, nil
+		}
+
+		// These errors describe the account or the caller, not the health of the provider,
+		// so there is no point in asking the next provider.
+		if errors.Is(err, instagram.ErrPrivateAccount) || errors.Is(err, instagram.ErrNotFound) ||
+			errors.Is(err, instagram.ErrHighlightNotFound) || errors.Is(err, instagram.ErrHighlightAlbumCollision) ||
+			errors.Is(err, instagram.ErrEmptyResult) {
+			p.recordSuccess()
+			return zero, name, err
+		}
+		if ctx.Err() != nil || errors.Is(err, ErrProcessorFailed) {
+			return zero, name, err
+		}
+		if errors.Is(err, instagram.ErrNotSupported) {
+			c.logger.Debug("Provider does not support method, trying next one", "method", method, "provider", name)
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+
+		c.logger.Warn("Provider failed, trying next one", "method", method, "provider", name, "error", err)
+		if p.recordFailure(err, c.failureThreshold, c.cooldown) {
+			c.logger.Error("Provider is cooling down after repeated failures",
+				"provider", name,
+				"cooldown", c.cooldown.String(),
+				"error", err,
+			)
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", name, err))
+	}
+
+	if len(errs) == 0 {
+		return zero, "", ErrNoProviders
+	}
+	if !sameErrorCode(errs) {
+		// The providers disagree on what went wrong, so treat it as an outage and let it be retried.
+		return zero, "", fmt.Errorf("all instagram providers failed: %w: %w", instagram.ErrProviderDown, errors.Join(errs...))
+	}
+	return zero, "", fmt.Errorf("all instagram providers failed: %w", errors.Join(errs...))
+}
+
+// sameErrorCode reports whether the providers that support the method failed the same way.
+func sameErrorCode(errs []error) bool {
+	code, seen := "", false
+	for _, err := range errs {
+		if errors.Is(err, instagram.ErrNotSupported) {
+			continue
+		}
+		if seen && apperrors.GetCode(err) != code {
+			return false
+		}
+		code, seen = apperrors.GetCode(err), true
+	}
+	return true
+}
+
+// candidates returns healthy providers in priority order. When every provider is cooling down
+// they are all returned, ordered by the end of their cool-down, so the bot never goes dark.
+// A provider named with instagram.WithProvider is the only candidate.
+func (c *Composite) candidates(ctx context.Context) []*providerState {
+	if name := instagram.ProviderFrom(ctx); name != "" {
+		for _, p := range c.providers {
+			if p.provider.Name == name {
+				return []*providerState{p}
+			}
+		}
+		return nil
+	}
+
+	now := time.Now()
+	var healthy, cooling []*providerState
+	for _, p := range c.providers {
+		p.mu.Lock()
+		coolingDown := now.Before(p.cooldownUntil)
+		p.mu.Unlock()
+		if coolingDown {
+			cooling = append(cooling, p)
+		} else {
+			healthy = append(healthy, p)
+		}
+	}
+
+	if len(healthy) > 0 {
+		return healthy
+	}
+
+	sort.SliceStable(cooling, func(i, j int) bool {
+		return cooling[i].cooldownEnd().Before(cooling[j].cooldownEnd())
+	})
+	return cooling
+}
+
+func (p *providerState) cooldownEnd() time.Time {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.cooldownUntil
+}
+
+func (p *providerState) recordSuccess() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.consecutiveFailures = 0
+	p.cooldownUntil = time.Time{}
+	p.lastSuccessAt = time.Now()
+}
+
+// recordFailure registers a failed call and reports whether the provider entered a cool-down.
+func (p *providerState) recordFailure(err error, threshold int, cooldown time.Duration) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now()
+	p.consecutiveFailures++
+	p.lastError = err.Error()
+	p.lastFailureAt = now
+	if p.consecutiveFailures >= threshold {
+		p.cooldownUntil = now.Add(cooldown)
+		return true
+	}
+	return false
+}
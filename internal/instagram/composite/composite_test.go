@@ -0,0 +1,182 @@
+package composite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram"
+	mock_instagram "github.com/orgball2608/insta-parser-telegram-bot/internal/instagram/mocks"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/config"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/logger"
+	"go.uber.org/mock/gomock"
+)
+
+// newTestComposite returns a composite of a primary and a fallback provider.
+func newTestComposite(t *testing.T, threshold int) (*Composite, *mock_instagram.MockClient, *mock_instagram.MockClient) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	primary := mock_instagram.NewMockClient(ctrl)
+	fallback := mock_instagram.NewMockClient(ctrl)
+
+	c, err := New(Opts{
+		Config: &config.Config{Parser: config.ParserConfig{
+			ProviderFailureThreshold: threshold,
+			ProviderCooldown:         time.Hour,
+		}},
+		Logger: logger.New(logger.Opts{Env: "test"}),
+		Providers: []instagram.Provider{
+			{Name: "primary", Client: primary},
+			{Name: "fallback", Client: fallback},
+		},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return c, primary, fallback
+}
+
+func TestFailover(t *testing.T) {
+	profile := &domain.UserProfile{Username: "someone"}
+
+	tests := []struct {
+		name         string
+		primaryErr   error
+		fallback     bool // Whether the fallback provider is asked
+		fallbackErr  error
+		want         []error
+		notWant      []error
+		wantProvider string
+	}{
+		{name: "primary serves", wantProvider: "primary"},
+		{name: "outage fails over", primaryErr: instagram.ErrTimeout, fallback: true, wantProvider: "fallback"},
+		{name: "unsupported fails over", primaryErr: instagram.ErrNotSupported, fallback: true, wantProvider: "fallback"},
+		{name: "missing account is final", primaryErr: instagram.ErrNotFound, want: []error{instagram.ErrNotFound}},
+		{name: "private account is final", primaryErr: instagram.ErrPrivateAccount, want: []error{instagram.ErrPrivateAccount}},
+		{name: "empty result is final", primaryErr: instagram.ErrEmptyResult, want: []error{instagram.ErrEmptyResult}},
+		{
+			name:       "same failure keeps its code",
+			primaryErr: instagram.ErrRateLimited, fallback: true, fallbackErr: instagram.ErrRateLimited,
+			want:    []error{instagram.ErrRateLimited},
+			notWant: []error{instagram.ErrProviderDown},
+		},
+		{
+			name:       "unsupported is ignored when comparing failures",
+			primaryErr: instagram.ErrNotSupported, fallback: true, fallbackErr: instagram.ErrSelectorMissing,
+			want:    []error{instagram.ErrSelectorMissing},
+			notWant: []error{instagram.ErrProviderDown},
+		},
+		{
+			name:       "mixed failures are an outage",
+			primaryErr: instagram.ErrTimeout, fallback: true, fallbackErr: instagram.ErrSelectorMissing,
+			want: []error{instagram.ErrProviderDown, instagram.ErrTimeout, instagram.ErrSelectorMissing},
+		},
+		{
+			name:       "unsupported everywhere",
+			primaryErr: instagram.ErrNotSupported, fallback: true, fallbackErr: instagram.ErrNotSupported,
+			want:    []error{instagram.ErrNotSupported},
+			notWant: []error{instagram.ErrProviderDown},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, primary, fallback := newTestComposite(t, 3)
+
+			primaryProfile := profile
+			if tt.primaryErr != nil {
+				primaryProfile = nil
+			}
+			primary.EXPECT().GetUserProfile(gomock.Any(), "someone").Return(primaryProfile, tt.primaryErr)
+			if tt.fallback {
+				fallbackProfile := profile
+				if tt.fallbackErr != nil {
+					fallbackProfile = nil
+				}
+				fallback.EXPECT().GetUserProfile(gomock.Any(), "someone").Return(fallbackProfile, tt.fallbackErr)
+			}
+
+			got, err := c.GetUserProfile(context.Background(), "someone")
+			for _, want := range tt.want {
+				if !errors.Is(err, want) {
+					t.Errorf("GetUserProfile() error = %v, want %v", err, want)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if errors.Is(err, notWant) {
+					t.Errorf("GetUserProfile() error = %v, should not be %v", err, notWant)
+				}
+			}
+			if len(tt.want) == 0 && err != nil {
+				t.Errorf("GetUserProfile() error = %v", err)
+			}
+			if tt.wantProvider != "" && (got == nil || got.Provider != tt.wantProvider) {
+				t.Errorf("GetUserProfile() = %+v, want it served by %s", got, tt.wantProvider)
+			}
+		})
+	}
+}
+
+func TestAccountErrorsDoNotCountAsFailures(t *testing.T) {
+	c, primary, _ := newTestComposite(t, 1)
+
+	primary.EXPECT().GetUserProfile(gomock.Any(), "nobody").Return(nil, instagram.ErrNotFound)
+	primary.EXPECT().GetUserProfile(gomock.Any(), "someone").Return(&domain.UserProfile{}, nil)
+
+	c.GetUserProfile(context.Background(), "nobody")
+	if _, err := c.GetUserProfile(context.Background(), "someone"); err != nil {
+		t.Fatalf("GetUserProfile() error = %v", err)
+	}
+	if status := c.ProviderStatuses()[0]; !status.Healthy || status.ConsecutiveFailures != 0 {
+		t.Errorf("primary provider status = %+v, want healthy", status)
+	}
+}
+
+func TestFailingProviderCoolsDown(t *testing.T) {
+	c, primary, fallback := newTestComposite(t, 1)
+
+	primary.EXPECT().GetUserProfile(gomock.Any(), "someone").Return(nil, instagram.ErrProviderDown)
+	fallback.EXPECT().GetUserProfile(gomock.Any(), "someone").Return(&domain.UserProfile{}, nil).Times(2)
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetUserProfile(context.Background(), "someone"); err != nil {
+			t.Fatalf("GetUserProfile() error = %v", err)
+		}
+	}
+	if status := c.ProviderStatuses()[0]; status.Healthy || status.ConsecutiveFailures != 1 {
+		t.Errorf("primary provider status = %+v, want cooling down after one failure", status)
+	}
+}
+
+func TestPinnedProvider(t *testing.T) {
+	c, _, fallback := newTestComposite(t, 1)
+
+	fallback.EXPECT().GetUserProfile(gomock.Any(), "someone").Return(nil, instagram.ErrTimeout)
+
+	_, err := c.GetUserProfile(instagram.WithProvider(context.Background(), "fallback"), "someone")
+	if !errors.Is(err, instagram.ErrTimeout) {
+		t.Fatalf("GetUserProfile() error = %v, want %v from the pinned provider", err, instagram.ErrTimeout)
+	}
+}
+
+func TestHighlightProcessorErrorIsReturned(t *testing.T) {
+	c, primary, _ := newTestComposite(t, 1)
+
+	processorErr := errors.New("telegram is down")
+	primary.EXPECT().GetUserHighlights(gomock.Any(), "someone", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, processor instagram.HighlightReelProcessorFunc) error {
+			return processor(domain.HighlightReel{Title: "Travel"})
+		})
+
+	err := c.GetUserHighlights(context.Background(), "someone", func(domain.HighlightReel) error {
+		return processorErr
+	})
+	if !errors.Is(err, ErrProcessorFailed) || !errors.Is(err, processorErr) {
+		t.Fatalf("GetUserHighlights() error = %v, want the processor error", err)
+	}
+	if status := c.ProviderStatuses()[0]; !status.Healthy {
+		t.Errorf("primary provider status = %+v, want healthy after a processor error", status)
+	}
+}
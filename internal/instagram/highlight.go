@@ -0,0 +1,60 @@
+package instagram
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// HighlightAlbumCover is the title and cover image of a highlight album as listed on a profile.
+type HighlightAlbumCover struct {
+	Title    string
+	CoverURL string
+}
+
+// HighlightAlbumID derives a stable identifier for a highlight album from its title and cover
+// image, for scrapers that do not expose Instagram's own highlight ID. Unlike the album's
+// position it survives the account adding or reordering highlights. Only an album whose cover
+// cannot be read falls back to its 1-based position in the list.
+func HighlightAlbumID(album HighlightAlbumCover, position int) string {
+	key := mediaFileName(album.CoverURL)
+	if key == "" || key == "." || key == "/" {
+		key = "#" + strconv.Itoa(position)
+	}
+	sum := sha1.Sum([]byte(strings.TrimSpace(album.Title) + "\x00" + key))
+	return hex.EncodeToString(sum[:8])
+}
+
+// HighlightAlbumIDs returns the IDs of the albums listed on a profile, in list order. Returns
+// ErrHighlightAlbumCollision when two albums get the same ID, since items could not be told
+// apart by album then.
+func HighlightAlbumIDs(albums []HighlightAlbumCover) ([]string, error) {
+	ids := make([]string, len(albums))
+	positions := make(map[string]int, len(albums))
+	for i, album := range albums {
+		id := HighlightAlbumID(album, i+1)
+		if first, ok := positions[id]; ok {
+			return nil, fmt.Errorf("albums #%d and #%d (%q): %w", first, i+1, album.Title, ErrHighlightAlbumCollision)
+		}
+		positions[id] = i + 1
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// mediaFileName returns the file name of a CDN URL without its signed query parameters, looking
+// through the scraper's proxy URL to the CDN URL nested in its "uri" parameter.
+func mediaFileName(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || rawURL == "" {
+		return rawURL
+	}
+	if nested, err := url.Parse(parsed.Query().Get("uri")); err == nil && nested.Path != "" {
+		parsed = nested
+	}
+	return path.Base(parsed.Path)
+}
@@ -0,0 +1,64 @@
+package instagram
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHighlightAlbumIDs(t *testing.T) {
+	tests := []struct {
+		name    string
+		albums  []HighlightAlbumCover
+		wantErr bool
+	}{
+		{
+			name: "same title, different covers",
+			albums: []HighlightAlbumCover{
+				{Title: "Travel", CoverURL: "https://cdn.example.com/v/a.jpg?sig=1"},
+				{Title: "Travel", CoverURL: "https://cdn.example.com/v/b.jpg?sig=1"},
+			},
+		},
+		{
+			name: "same title, missing covers",
+			albums: []HighlightAlbumCover{
+				{Title: "Travel"},
+				{Title: "Travel"},
+			},
+		},
+		{
+			name: "same title and cover",
+			albums: []HighlightAlbumCover{
+				{Title: "Travel", CoverURL: "https://cdn.example.com/v/a.jpg?sig=1"},
+				{Title: "Travel", CoverURL: "https://cdn.example.com/v/a.jpg?sig=2"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, err := HighlightAlbumIDs(tt.albums)
+			if tt.wantErr {
+				if !errors.Is(err, ErrHighlightAlbumCollision) {
+					t.Fatalf("HighlightAlbumIDs() error = %v, want %v", err, ErrHighlightAlbumCollision)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("HighlightAlbumIDs() error = %v", err)
+			}
+			if len(ids) != len(tt.albums) || ids[0] == ids[1] {
+				t.Errorf("HighlightAlbumIDs() = %v, want distinct IDs", ids)
+			}
+		})
+	}
+}
+
+func TestHighlightAlbumIDIgnoresSignature(t *testing.T) {
+	first := HighlightAlbumID(HighlightAlbumCover{Title: "Travel", CoverURL: "https://cdn.example.com/v/a.jpg?sig=1"}, 1)
+	// Moved to another position with a freshly signed cover URL
+	second := HighlightAlbumID(HighlightAlbumCover{Title: "Travel", CoverURL: "https://cdn.example.com/v/a.jpg?sig=2"}, 3)
+	if first != second {
+		t.Errorf("HighlightAlbumID() = %s and %s, want the same ID", first, second)
+	}
+}
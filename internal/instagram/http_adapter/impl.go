@@ -0,0 +1,234 @@
+package http_adapter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/config"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/logger"
+	"go.uber.org/fx"
+)
+
+// ProviderName is the name under which the HTTP scraper is registered.
+const ProviderName = "http"
+
+const userAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.0.0 Safari/537.36"
+
+// Endpoints used by the scraper site's own frontend, relative to config.ParserConfig.HTTPBaseURL.
+const (
+	userInfoPath = "/api/ig/userInfoByUsername/"
+	storiesPath  = "/api/ig/stories/"
+	postsPath    = "/api/ig/posts/"
+	convertPath  = "/api/convert"
+)
+
+type Opts struct {
+	fx.In
+
+	Config     *config.Config
+	Logger     logger.Logger
+	HTTPClient *http.Client `optional:"true"`
+}
+
+// HTTPAdapter talks to the scraper site's JSON API directly, without a browser.
+type HTTPAdapter struct {
+	baseURL string
+	client  *http.Client
+	logger  logger.Logger
+}
+
+var _ instagram.Client = (*HTTPAdapter)(nil)
+
+func New(opts Opts) *HTTPAdapter {
+	client := opts.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: opts.Config.Parser.HTTPTimeout}
+	}
+
+	return &HTTPAdapter{
+		baseURL: strings.TrimRight(opts.Config.Parser.HTTPBaseURL, "/"),
+		client:  client,
+		logger:  opts.Logger.WithComponent("HTTPAdapter"),
+	}
+}
+
+// NewProvider registers the HTTP scraper in the instagram provider registry.
+func NewProvider(opts Opts) instagram.Provider {
+	return instagram.Provider{
+		Name:   ProviderName,
+		Client: New(opts),
+	}
+}
+
+func (a *HTTPAdapter) GetUserHighlights(_ context.Context, _ string, _ instagram.HighlightReelProcessorFunc) error {
+	return instagram.ErrNotSupported
+}
+
+func (a *HTTPAdapter) GetHighlightAlbumPreviews(_ context.Context, _ string) ([]domain.HighlightAlbumPreview, error) {
+	return nil, instagram.ErrNotSupported
+}
+
+func (a *HTTPAdapter) GetSingleHighlightAlbum(_ context.Context, _, _ string) (*domain.HighlightReel, error) {
+	return nil, instagram.ErrNotSupported
+}
+
+func (a *HTTPAdapter) GetUserProfile(ctx context.Context, userName string) (*domain.UserProfile, error) {
+	a.logger.Info("Fetching profile via HTTP API", "user", userName)
+
+	user, err := a.fetchUser(ctx, userName)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := user.toUserProfile()
+	if profile.Username == "" {
+		profile.Username = userName
+	}
+	return &profile, nil
+}
+
+// GetProfileSnapshot resolves the account once for its profile, stories and posts. The API
+// has no highlights listing, so the snapshot has none.
+func (a *HTTPAdapter) GetProfileSnapshot(ctx context.Context, userName string) (*domain.AccountSnapshot, error) {
+	a.logger.Info("Fetching profile snapshot via HTTP API", "user", userName)
+
+	user, err := a.fetchUser(ctx, userName)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := user.toUserProfile()
+	if profile.Username == "" {
+		profile.Username = userName
+	}
+	snapshot := &domain.AccountSnapshot{Profile: profile}
+	if user.IsPrivate {
+		return snapshot, nil
+	}
+
+	if snapshot.Stories, err = a.listStories(ctx, user, userName); err != nil {
+		return nil, err
+	}
+	if snapshot.Posts, err = a.listPosts(ctx, user, userName); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// fetchUser loads the account's user info, including private accounts.
+func (a *HTTPAdapter) fetchUser(ctx context.Context, userName string) (*userInfo, error) {
+	var resp userInfoResponse
+	if err := a.getJSON(ctx, userInfoPath+url.PathEscape(userName), &resp); err != nil {
+		return nil, fmt.Errorf("could not resolve user %s: %w", userName, err)
+	}
+
+	user := resp.Result.User
+	if user.PK.String() == "" {
+		return nil, fmt.Errorf("account %s: %w", userName, instagram.ErrNotFound)
+	}
+	return &user, nil
+}
+
+// resolveUser looks up the numeric Instagram user ID that the other endpoints are keyed by.
+func (a *HTTPAdapter) resolveUser(ctx context.Context, userName string) (*userInfo, error) {
+	user, err := a.fetchUser(ctx, userName)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsPrivate {
+		a.logger.Warn("Account is private", "user", userName)
+		return nil, instagram.ErrPrivateAccount
+	}
+
+	return user, nil
+}
+
+func (a *HTTPAdapter) getJSON(ctx context.Context, path string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.baseURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("error creating HTTP request: %w", err)
+	}
+	return a.do(req, out)
+}
+
+func (a *HTTPAdapter) postJSON(ctx context.Context, path string, body any, out any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("could not encode request body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.baseURL+path, strings.NewReader(string(payload)))
+	if err != nil {
+		return fmt.Errorf("error creating HTTP request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return a.do(req, out)
+}
+
+func (a *HTTPAdapter) do(req *http.Request, out any) error {
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Origin", a.baseURL)
+	req.Header.Set("Referer", a.baseURL+"/")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("http client error: %w", classifyClientError(req, err))
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			a.logger.Error("Error closing response body", "error", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+		return fmt.Errorf("bad status code %d from %s: %s: %w", resp.StatusCode, req.URL.Path, strings.TrimSpace(string(snippet)), statusError(resp.StatusCode))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("could not decode response from %s: %w: %w", req.URL.Path, instagram.ErrSelectorMissing, err)
+	}
+	return nil
+}
+
+// classifyClientError tags a transport failure as a timeout or an unreachable site. A request
+// cancelled by its caller is left as is, so it is not mistaken for a failing provider.
+func classifyClientError(req *http.Request, err error) error {
+	if errors.Is(req.Context().Err(), context.Canceled) {
+		return err
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return fmt.Errorf("%w: %w", instagram.ErrTimeout, err)
+	}
+	return fmt.Errorf("%w: %w", instagram.ErrProviderDown, err)
+}
+
+// statusError maps a failed response to a scraper error. Other client errors mean the API
+// no longer accepts our requests, the HTTP counterpart of a selector that stopped matching.
+func statusError(code int) error {
+	switch {
+	case code == http.StatusNotFound:
+		return instagram.ErrNotFound
+	case code == http.StatusTooManyRequests, code == http.StatusForbidden:
+		return instagram.ErrRateLimited
+	case code == http.StatusGatewayTimeout:
+		return instagram.ErrTimeout
+	case code >= http.StatusInternalServerError:
+		return instagram.ErrProviderDown
+	default:
+		return instagram.ErrSelectorMissing
+	}
+}
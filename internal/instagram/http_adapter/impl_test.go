@@ -0,0 +1,163 @@
+package http_adapter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/config"
+	apperrors "github.com/orgball2608/insta-parser-telegram-bot/pkg/errors"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/logger"
+)
+
+// newTestAdapter starts a scraper API serving routes and returns an adapter pointed at it.
+func newTestAdapter(t *testing.T, routes map[string]http.HandlerFunc) *HTTPAdapter {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	for pattern, handler := range routes {
+		mux.HandleFunc(pattern, handler)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	return New(Opts{
+		Config: &config.Config{Parser: config.ParserConfig{
+			HTTPBaseURL: srv.URL + "/",
+			HTTPTimeout: 5 * time.Second,
+		}},
+		Logger: logger.New(logger.Opts{Env: "test"}),
+	})
+}
+
+// fixture serves a recorded response from testdata.
+func fixture(t *testing.T, name string) http.HandlerFunc {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("could not read fixture %s: %v", name, err)
+	}
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	}
+}
+
+func status(code int) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, http.StatusText(code), code)
+	}
+}
+
+func TestStatusErrorMapping(t *testing.T) {
+	tests := []struct {
+		status    int
+		want      error
+		transient bool
+	}{
+		{status: http.StatusNotFound, want: instagram.ErrNotFound},
+		{status: http.StatusTooManyRequests, want: instagram.ErrRateLimited, transient: true},
+		{status: http.StatusForbidden, want: instagram.ErrRateLimited, transient: true},
+		{status: http.StatusInternalServerError, want: instagram.ErrProviderDown, transient: true},
+		{status: http.StatusBadGateway, want: instagram.ErrProviderDown, transient: true},
+		{status: http.StatusServiceUnavailable, want: instagram.ErrProviderDown, transient: true},
+		{status: http.StatusGatewayTimeout, want: instagram.ErrTimeout, transient: true},
+		{status: http.StatusBadRequest, want: instagram.ErrSelectorMissing},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			a := newTestAdapter(t, map[string]http.HandlerFunc{
+				userInfoPath: status(tt.status),
+			})
+
+			_, err := a.GetUserStories(context.Background(), "natgeo")
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("GetUserStories() error = %v, want %v", err, tt.want)
+			}
+			if got := apperrors.IsTransient(err); got != tt.transient {
+				t.Errorf("IsTransient() = %v, want %v", got, tt.transient)
+			}
+		})
+	}
+}
+
+func TestStatusErrorOnMediaEndpoint(t *testing.T) {
+	a := newTestAdapter(t, map[string]http.HandlerFunc{
+		userInfoPath: fixture(t, "user_info.json"),
+		storiesPath:  status(http.StatusTooManyRequests),
+	})
+
+	_, err := a.GetUserStories(context.Background(), "natgeo")
+	if !errors.Is(err, instagram.ErrRateLimited) {
+		t.Fatalf("GetUserStories() error = %v, want %v", err, instagram.ErrRateLimited)
+	}
+}
+
+func TestUnreachableProvider(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+
+	a := New(Opts{
+		Config: &config.Config{Parser: config.ParserConfig{HTTPBaseURL: srv.URL, HTTPTimeout: time.Second}},
+		Logger: logger.New(logger.Opts{Env: "test"}),
+	})
+
+	_, err := a.GetUserProfile(context.Background(), "natgeo")
+	if !errors.Is(err, instagram.ErrProviderDown) {
+		t.Fatalf("GetUserProfile() error = %v, want %v", err, instagram.ErrProviderDown)
+	}
+}
+
+func TestMalformedResponse(t *testing.T) {
+	a := newTestAdapter(t, map[string]http.HandlerFunc{
+		userInfoPath: func(w http.ResponseWriter, _ *http.Request) {
+			w.Write([]byte("<html>maintenance</html>"))
+		},
+	})
+
+	_, err := a.GetUserProfile(context.Background(), "natgeo")
+	if !errors.Is(err, instagram.ErrSelectorMissing) {
+		t.Fatalf("GetUserProfile() error = %v, want %v", err, instagram.ErrSelectorMissing)
+	}
+}
+
+func TestMissingAccount(t *testing.T) {
+	a := newTestAdapter(t, map[string]http.HandlerFunc{
+		userInfoPath: fixture(t, "user_info_missing.json"),
+	})
+
+	_, err := a.GetUserProfile(context.Background(), "nobody")
+	if !errors.Is(err, instagram.ErrNotFound) {
+		t.Fatalf("GetUserProfile() error = %v, want %v", err, instagram.ErrNotFound)
+	}
+}
+
+func TestPrivateAccount(t *testing.T) {
+	a := newTestAdapter(t, map[string]http.HandlerFunc{
+		userInfoPath: fixture(t, "user_info_private.json"),
+		storiesPath: func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("stories of a private account were requested: %s", r.URL.Path)
+		},
+	})
+
+	_, err := a.GetUserStories(context.Background(), "hidden")
+	if !errors.Is(err, instagram.ErrPrivateAccount) {
+		t.Fatalf("GetUserStories() error = %v, want %v", err, instagram.ErrPrivateAccount)
+	}
+
+	profile, err := a.GetUserProfile(context.Background(), "hidden")
+	if err != nil {
+		t.Fatalf("GetUserProfile() error = %v", err)
+	}
+	if !profile.IsPrivate || profile.FollowerCount != 120 {
+		t.Errorf("GetUserProfile() = %+v, want a private profile with 120 followers", profile)
+	}
+}
@@ -0,0 +1,94 @@
+package http_adapter
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram"
+)
+
+// maxListedPosts mirrors the number of posts the browser scraper returns.
+const maxListedPosts = 12
+
+func (a *HTTPAdapter) GetUserPosts(ctx context.Context, userName string) ([]domain.PostItem, error) {
+	a.logger.Info("Fetching posts via HTTP API", "user", userName)
+
+	user, err := a.resolveUser(ctx, userName)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.listPosts(ctx, user, userName)
+}
+
+func (a *HTTPAdapter) listPosts(ctx context.Context, user *userInfo, userName string) ([]domain.PostItem, error) {
+	var resp mediaListResponse
+	if err := a.getJSON(ctx, postsPath+url.PathEscape(user.PK.String()), &resp); err != nil {
+		return nil, fmt.Errorf("could not fetch posts for %s: %w", userName, err)
+	}
+
+	posts := make([]domain.PostItem, 0, len(resp.Result))
+	for _, item := range resp.Result {
+		if len(posts) >= maxListedPosts {
+			break
+		}
+		post := item.toPostItem(userName)
+		if post.ID == "" || post.PostURL == "" {
+			a.logger.Warn("Skipping post without ID or shortcode", "user", userName, "pk", item.PK.String())
+			continue
+		}
+		posts = append(posts, post)
+	}
+
+	a.logger.Info("Fetched posts via HTTP API", "user", userName, "count", len(posts))
+	return posts, nil
+}
+
+// GetUserReels returns the reels among the user's latest posts; the API has no separate
+// reels listing.
+func (a *HTTPAdapter) GetUserReels(ctx context.Context, userName string) ([]domain.PostItem, error) {
+	posts, err := a.GetUserPosts(ctx, userName)
+	if err != nil {
+		return nil, err
+	}
+
+	reels := make([]domain.PostItem, 0, len(posts))
+	for _, post := range posts {
+		if post.IsReel {
+			reels = append(reels, post)
+		}
+	}
+	return reels, nil
+}
+
+func (a *HTTPAdapter) GetUserPost(ctx context.Context, postURL string) (*domain.PostItem, error) {
+	return a.convert(ctx, postURL, "post")
+}
+
+func (a *HTTPAdapter) GetUserReel(ctx context.Context, reelURL string) (*domain.PostItem, error) {
+	return a.convert(ctx, reelURL, "reel")
+}
+
+// convert resolves a single post or reel URL through the scraper's convert endpoint.
+func (a *HTTPAdapter) convert(ctx context.Context, mediaURL string, mediaType string) (*domain.PostItem, error) {
+	a.logger.Info("Fetching media via HTTP API", "type", mediaType, "url", mediaURL)
+
+	var resp mediaResponse
+	if err := a.postJSON(ctx, convertPath, map[string]string{"url": mediaURL}, &resp); err != nil {
+		return nil, fmt.Errorf("could not fetch %s: %w", mediaType, err)
+	}
+
+	post := resp.Result.toPostItem("")
+	if len(post.Media) == 0 {
+		return nil, fmt.Errorf("no media found for %s %s: %w", mediaType, mediaURL, instagram.ErrEmptyResult)
+	}
+	if post.PostURL == "" {
+		post.PostURL = mediaURL
+		post.URL = mediaURL
+	}
+
+	a.logger.Info("Fetched media via HTTP API", "type", mediaType, "url", mediaURL, "media_count", len(post.Media))
+	return &post, nil
+}
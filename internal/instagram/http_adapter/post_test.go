@@ -0,0 +1,97 @@
+package http_adapter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram"
+)
+
+func TestGetUserPosts(t *testing.T) {
+	a := newTestAdapter(t, map[string]http.HandlerFunc{
+		userInfoPath:             fixture(t, "user_info.json"),
+		postsPath + "1234567890": fixture(t, "posts.json"),
+	})
+
+	posts, err := a.GetUserPosts(context.Background(), "natgeo")
+	if err != nil {
+		t.Fatalf("GetUserPosts() error = %v", err)
+	}
+	if len(posts) != 2 {
+		t.Fatalf("GetUserPosts() returned %d posts, want 2", len(posts))
+	}
+
+	carousel := posts[0]
+	if carousel.ID != "C9xAbCdEfGh" || carousel.PostURL != "https://www.instagram.com/p/C9xAbCdEfGh/" || carousel.IsReel {
+		t.Errorf("carousel post = %+v", carousel)
+	}
+	if carousel.Caption != "Glaciers at sunrise." || carousel.LikeCount != 152000 {
+		t.Errorf("carousel caption/likes = %q/%d", carousel.Caption, carousel.LikeCount)
+	}
+	if len(carousel.Media) != 2 || carousel.Media[0].Type != domain.MediaTypeImage || carousel.Media[1].Type != domain.MediaTypeVideo {
+		t.Errorf("carousel media = %+v, want an image and a video", carousel.Media)
+	}
+
+	reel := posts[1]
+	if reel.ID != "C9yReElCoDe" || !reel.IsReel || reel.PostURL != "https://www.instagram.com/reel/C9yReElCoDe/" {
+		t.Errorf("reel post = %+v", reel)
+	}
+	if reel.Caption != "" || len(reel.Media) != 1 || reel.Media[0].URL != "https://scontent.cdninstagram.com/o1/v/reel_720.mp4" {
+		t.Errorf("reel caption/media = %q/%+v", reel.Caption, reel.Media)
+	}
+}
+
+func TestGetUserReels(t *testing.T) {
+	a := newTestAdapter(t, map[string]http.HandlerFunc{
+		userInfoPath: fixture(t, "user_info.json"),
+		postsPath:    fixture(t, "posts.json"),
+	})
+
+	reels, err := a.GetUserReels(context.Background(), "natgeo")
+	if err != nil {
+		t.Fatalf("GetUserReels() error = %v", err)
+	}
+	if len(reels) != 1 || reels[0].ID != "C9yReElCoDe" {
+		t.Errorf("GetUserReels() = %+v, want only the reel", reels)
+	}
+}
+
+func TestGetUserPost(t *testing.T) {
+	const postURL = "https://www.instagram.com/p/C9xAbCdEfGh/"
+
+	a := newTestAdapter(t, map[string]http.HandlerFunc{
+		convertPath: func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["url"] != postURL {
+				t.Errorf("convert request body = %v (%v), want url %s", body, err, postURL)
+			}
+			w.Write([]byte(`{"result": {"code": "C9xAbCdEfGh", "media_type": 1, "user": {"username": "natgeo"},
+				"image_versions2": {"candidates": [{"url": "https://scontent.cdninstagram.com/v/single.jpg", "width": 1080, "height": 1080}]}}}`))
+		},
+	})
+
+	post, err := a.GetUserPost(context.Background(), postURL)
+	if err != nil {
+		t.Fatalf("GetUserPost() error = %v", err)
+	}
+	if post.Username != "natgeo" || len(post.Media) != 1 || post.Media[0].URL != "https://scontent.cdninstagram.com/v/single.jpg" {
+		t.Errorf("GetUserPost() = %+v", post)
+	}
+}
+
+func TestGetUserPostWithoutMedia(t *testing.T) {
+	a := newTestAdapter(t, map[string]http.HandlerFunc{
+		convertPath: func(w http.ResponseWriter, _ *http.Request) {
+			w.Write([]byte(`{"result": {}}`))
+		},
+	})
+
+	_, err := a.GetUserPost(context.Background(), "https://www.instagram.com/p/C9xAbCdEfGh/")
+	if !errors.Is(err, instagram.ErrEmptyResult) {
+		t.Fatalf("GetUserPost() error = %v, want %v", err, instagram.ErrEmptyResult)
+	}
+}
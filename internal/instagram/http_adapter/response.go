@@ -0,0 +1,197 @@
+package http_adapter
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram"
+)
+
+// Instagram media_type values as returned by the scraper API.
+const (
+	mediaTypePhoto    = 1
+	mediaTypeVideo    = 2
+	mediaTypeCarousel = 8
+)
+
+type userInfoResponse struct {
+	Result struct {
+		User userInfo `json:"user"`
+	} `json:"result"`
+}
+
+type userInfo struct {
+	PK             json.Number `json:"pk"`
+	Username       string      `json:"username"`
+	FullName       string      `json:"full_name"`
+	Biography      string      `json:"biography"`
+	IsPrivate      bool        `json:"is_private"`
+	IsVerified     bool        `json:"is_verified"`
+	FollowerCount  int         `json:"follower_count"`
+	FollowingCount int         `json:"following_count"`
+	MediaCount     int         `json:"media_count"`
+	ProfilePicURL  string      `json:"profile_pic_url"`
+	HDProfilePic   struct {
+		URL string `json:"url"`
+	} `json:"hd_profile_pic_url_info"`
+}
+
+func (u userInfo) toUserProfile() domain.UserProfile {
+	avatarURL := u.HDProfilePic.URL
+	if avatarURL == "" {
+		avatarURL = u.ProfilePicURL
+	}
+
+	return domain.UserProfile{
+		Username:       u.Username,
+		FullName:       u.FullName,
+		Biography:      u.Biography,
+		AvatarURL:      avatarURL,
+		FollowerCount:  u.FollowerCount,
+		FollowingCount: u.FollowingCount,
+		PostCount:      u.MediaCount,
+		IsVerified:     u.IsVerified,
+		IsPrivate:      u.IsPrivate,
+	}
+}
+
+type mediaListResponse struct {
+	Result []mediaItem `json:"result"`
+}
+
+type mediaResponse struct {
+	Result mediaItem `json:"result"`
+}
+
+type imageCandidate struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+type mediaItem struct {
+	PK            json.Number `json:"pk"`
+	Code          string      `json:"code"`
+	TakenAt       int64       `json:"taken_at"`
+	MediaType     int         `json:"media_type"`
+	ProductType   string      `json:"product_type"`
+	LikeCount     int         `json:"like_count"`
+	VideoDuration float64     `json:"video_duration"`
+	User          struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	Caption *struct {
+		Text string `json:"text"`
+	} `json:"caption"`
+	ImageVersions struct {
+		Candidates []imageCandidate `json:"candidates"`
+	} `json:"image_versions2"`
+	VideoVersions []imageCandidate `json:"video_versions"`
+	CarouselMedia []mediaItem      `json:"carousel_media"`
+}
+
+func (m mediaItem) isVideo() bool {
+	return m.MediaType == mediaTypeVideo || len(m.VideoVersions) > 0
+}
+
+// toMediaItem converts the item's renditions into a domain media item, largest first.
+func (m mediaItem) toMediaItem() domain.MediaItem {
+	mediaType := domain.MediaTypeImage
+	candidates := m.ImageVersions.Candidates
+	if m.isVideo() {
+		mediaType = domain.MediaTypeVideo
+		candidates = m.VideoVersions
+	}
+
+	item := domain.NewMediaItem(mediaType, candidatesToResolutions(candidates))
+	if m.isVideo() {
+		item.Duration = time.Duration(m.VideoDuration * float64(time.Second))
+		// For videos the image versions are the cover frames.
+		if thumb := domain.NewMediaItem(domain.MediaTypeImage, candidatesToResolutions(m.ImageVersions.Candidates)); thumb.URL != "" {
+			item.ThumbnailURL = thumb.URL
+		}
+	}
+	return item
+}
+
+func candidatesToResolutions(candidates []imageCandidate) []domain.MediaResolution {
+	resolutions := make([]domain.MediaResolution, 0, len(candidates))
+	for _, c := range candidates {
+		resolutions = append(resolutions, domain.MediaResolution{URL: c.URL, Width: c.Width, Height: c.Height})
+	}
+	return resolutions
+}
+
+func (m mediaItem) takenAt() time.Time {
+	if m.TakenAt == 0 {
+		return time.Time{}
+	}
+	return time.Unix(m.TakenAt, 0)
+}
+
+// shortcode is the post's permalink code; it is derived from the pk when the API omits it.
+func (m mediaItem) shortcode() string {
+	if m.Code != "" {
+		return m.Code
+	}
+	code, _ := instagram.ShortcodeFromMediaID(m.PK.String())
+	return code
+}
+
+// isReel reports whether the item was published as a reel.
+func (m mediaItem) isReel() bool {
+	return m.ProductType == "clips"
+}
+
+func (m mediaItem) permalink() string {
+	code := m.shortcode()
+	if code == "" {
+		return ""
+	}
+	return instagram.Permalink(code, m.isReel())
+}
+
+func (m mediaItem) toStoryItem(userName string) domain.StoryItem {
+	media := m.toMediaItem()
+
+	return domain.StoryItem{
+		ID:        m.PK.String(),
+		MediaURL:  media.URL,
+		MediaType: media.Type,
+		TakenAt:   m.takenAt(),
+		Username:  userName,
+		Media:     media,
+	}
+}
+
+func (m mediaItem) toPostItem(userName string) domain.PostItem {
+	if m.User.Username != "" {
+		userName = m.User.Username
+	}
+
+	post := domain.PostItem{
+		ID:       m.shortcode(),
+		PostURL:  m.permalink(),
+		URL:      m.permalink(),
+		Username: userName,
+		TakenAt:  m.takenAt(),
+		IsReel:   m.isReel(),
+	}
+	if m.Caption != nil {
+		post.Caption = m.Caption.Text
+	}
+	post.LikeCount = m.LikeCount
+
+	children := m.CarouselMedia
+	if m.MediaType != mediaTypeCarousel || len(children) == 0 {
+		children = []mediaItem{m}
+	}
+	for _, child := range children {
+		if media := child.toMediaItem(); media.URL != "" {
+			post.Media = append(post.Media, media)
+		}
+	}
+
+	return post
+}
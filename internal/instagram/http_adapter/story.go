@@ -0,0 +1,45 @@
+package http_adapter
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+)
+
+func (a *HTTPAdapter) GetUserStories(ctx context.Context, userName string) ([]domain.StoryItem, error) {
+	a.logger.Info("Fetching stories via HTTP API", "user", userName)
+
+	user, err := a.resolveUser(ctx, userName)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.listStories(ctx, user, userName)
+}
+
+func (a *HTTPAdapter) listStories(ctx context.Context, user *userInfo, userName string) ([]domain.StoryItem, error) {
+	var resp mediaListResponse
+	if err := a.getJSON(ctx, storiesPath+url.PathEscape(user.PK.String()), &resp); err != nil {
+		return nil, fmt.Errorf("could not fetch stories for %s: %w", userName, err)
+	}
+
+	stories := make([]domain.StoryItem, 0, len(resp.Result))
+	for _, item := range resp.Result {
+		story := item.toStoryItem(userName)
+		if story.ID == "" || story.MediaURL == "" {
+			a.logger.Warn("Skipping story without ID or media", "user", userName, "pk", item.PK.String())
+			continue
+		}
+		stories = append(stories, story)
+	}
+
+	sort.SliceStable(stories, func(i, j int) bool {
+		return stories[i].TakenAt.Before(stories[j].TakenAt)
+	})
+
+	a.logger.Info("Fetched stories via HTTP API", "user", userName, "count", len(stories))
+	return stories, nil
+}
@@ -0,0 +1,68 @@
+package http_adapter
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+)
+
+func TestGetUserStories(t *testing.T) {
+	a := newTestAdapter(t, map[string]http.HandlerFunc{
+		userInfoPath:               fixture(t, "user_info.json"),
+		storiesPath + "1234567890": fixture(t, "stories.json"),
+	})
+
+	stories, err := a.GetUserStories(context.Background(), "natgeo")
+	if err != nil {
+		t.Fatalf("GetUserStories() error = %v", err)
+	}
+
+	// The story without media is skipped and the rest come oldest first.
+	want := []struct {
+		id        string
+		mediaType domain.MediaType
+		url       string
+		takenAt   int64
+	}{
+		{id: "3400000000000000001", mediaType: domain.MediaTypeImage, url: "https://scontent.cdninstagram.com/v/story1_1080.jpg", takenAt: 1723708800},
+		{id: "3400000000000000002", mediaType: domain.MediaTypeVideo, url: "https://scontent.cdninstagram.com/o1/v/story2_720.mp4", takenAt: 1723712400},
+	}
+	if len(stories) != len(want) {
+		t.Fatalf("GetUserStories() returned %d stories, want %d", len(stories), len(want))
+	}
+	for i, w := range want {
+		got := stories[i]
+		if got.ID != w.id || got.MediaType != w.mediaType || got.MediaURL != w.url || !got.TakenAt.Equal(time.Unix(w.takenAt, 0)) {
+			t.Errorf("story %d = %+v, want %+v", i, got, w)
+		}
+		if got.Username != "natgeo" {
+			t.Errorf("story %d username = %q, want natgeo", i, got.Username)
+		}
+	}
+
+	video := stories[1].Media
+	if video.Duration != 14500*time.Millisecond {
+		t.Errorf("video duration = %v, want 14.5s", video.Duration)
+	}
+	if video.ThumbnailURL != "https://scontent.cdninstagram.com/v/story2_cover.jpg" {
+		t.Errorf("video thumbnail = %q", video.ThumbnailURL)
+	}
+}
+
+func TestGetUserStoriesEmpty(t *testing.T) {
+	a := newTestAdapter(t, map[string]http.HandlerFunc{
+		userInfoPath: fixture(t, "user_info.json"),
+		storiesPath:  fixture(t, "stories_empty.json"),
+	})
+
+	stories, err := a.GetUserStories(context.Background(), "natgeo")
+	if err != nil {
+		t.Fatalf("GetUserStories() error = %v, want no error for an account without stories", err)
+	}
+	if stories == nil || len(stories) != 0 {
+		t.Errorf("GetUserStories() = %#v, want an empty list", stories)
+	}
+}
@@ -3,20 +3,119 @@ package instagram
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	apperrors "github.com/orgball2608/insta-parser-telegram-bot/pkg/errors"
 )
 
-var ErrPrivateAccount = errors.New("account is private and cannot be accessed")
+// Scraper failures. Providers wrap the cause in one of these, so callers can tell them apart
+// with errors.Is and retry only the transient ones (see apperrors.IsTransient).
+var (
+	ErrPrivateAccount = apperrors.NewWithCode(apperrors.CodePrivate, "account is private and cannot be accessed")
+	ErrNotFound       = apperrors.NewWithCode(apperrors.CodeNotFound, "account or media not found")
+	// ErrHighlightNotFound means the requested highlight album is no longer on the profile,
+	// usually because it was renamed, re-covered or deleted since it was listed.
+	ErrHighlightNotFound = apperrors.NewWithCode(apperrors.CodeNotFound, "highlight album not found")
+	// ErrHighlightAlbumCollision means two highlight albums of an account have the same title
+	// and cover, so they cannot be told apart (see HighlightAlbumIDs).
+	ErrHighlightAlbumCollision = errors.New("two highlight albums have the same ID")
+	ErrRateLimited             = apperrors.NewWithCode(apperrors.CodeRateLimited, "rate limited by the scraper site")
+	// ErrSelectorMissing means an element the scraper profile expects is not on the page,
+	// which usually means the site changed its layout.
+	ErrSelectorMissing = apperrors.NewWithCode(apperrors.CodeSelectorMissing, "expected page element is missing")
+	ErrTimeout         = apperrors.NewWithCode(apperrors.CodeTimeout, "scraper site did not answer in time")
+	ErrEmptyResult     = apperrors.NewWithCode(apperrors.CodeEmptyResult, "scraper returned no media")
+	ErrProviderDown    = apperrors.NewWithCode(apperrors.CodeProviderDown, "scraper provider is unavailable")
+
+	ErrNotSupported = errors.New("operation is not supported by this provider")
+)
+
+// Scrape steps reported by StepError.
+const (
+	StepNavigation      = "page navigation"
+	StepSearchForm      = "search form"
+	StepSearchResults   = "search results"
+	StepStoriesTab      = "stories tab"
+	StepHighlightsTab   = "highlights tab"
+	StepPostsTab        = "posts tab"
+	StepReelsTab        = "reels tab"
+	StepProfileHeader   = "profile header"
+	StepHighlightAlbums = "highlight albums"
+	StepMediaList       = "media list"
+	StepDownloadButtons = "download buttons"
+	StepMediaResult     = "media result"
+)
+
+// StepError reports which step of a scrape failed, so selector drift on the scraper site
+// can be pinpointed. Screenshot is the path of a page screenshot taken at the time, if any.
+type StepError struct {
+	Step       string
+	Screenshot string
+	Err        error
+}
+
+func (e *StepError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Step, e.Err)
+}
+
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
 
 type HighlightReelProcessorFunc func(reel domain.HighlightReel) error
 
+//go:generate go run go.uber.org/mock/mockgen -source=instagram.go -destination=mocks/mock.go
 type Client interface {
-	GetUserStories(userName string) ([]domain.StoryItem, error)
-	GetUserHighlights(userName string, processorFunc HighlightReelProcessorFunc) error
-	GetHighlightAlbumPreviews(userName string) ([]domain.HighlightAlbumPreview, error)
-	GetSingleHighlightAlbum(userName, albumID string) (*domain.HighlightReel, error)
+	GetUserStories(ctx context.Context, userName string) ([]domain.StoryItem, error)
+	GetUserHighlights(ctx context.Context, userName string, processorFunc HighlightReelProcessorFunc) error
+	GetHighlightAlbumPreviews(ctx context.Context, userName string) ([]domain.HighlightAlbumPreview, error)
+	GetSingleHighlightAlbum(ctx context.Context, userName, albumID string) (*domain.HighlightReel, error)
 	GetUserPost(ctx context.Context, postURL string) (*domain.PostItem, error)
 	GetUserReel(ctx context.Context, reelURL string) (*domain.PostItem, error)
 	GetUserPosts(ctx context.Context, userName string) ([]domain.PostItem, error)
+	GetUserReels(ctx context.Context, userName string) ([]domain.PostItem, error)
+	GetUserProfile(ctx context.Context, userName string) (*domain.UserProfile, error)
+	// GetProfileSnapshot collects the profile header, stories, latest posts and highlight
+	// previews of an account in one visit, for callers that need several of them.
+	GetProfileSnapshot(ctx context.Context, userName string) (*domain.AccountSnapshot, error)
+}
+
+// Provider is a named Client implementation that can be registered with the provider registry.
+type Provider struct {
+	Name   string
+	Client Client
+}
+
+type providerKey struct{}
+
+// WithProvider returns a context whose scraper calls are served by the named provider only,
+// even while it cools down, without falling back to another one or using cached results.
+func WithProvider(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, providerKey{}, name)
+}
+
+// ProviderFrom returns the provider named with WithProvider, or "" when any provider may serve
+// the call.
+func ProviderFrom(ctx context.Context) string {
+	name, _ := ctx.Value(providerKey{}).(string)
+	return name
+}
+
+// ProviderStatus describes the health of a single registered provider.
+type ProviderStatus struct {
+	Name                string
+	Priority            int
+	Healthy             bool
+	ConsecutiveFailures int
+	LastError           string
+	LastFailureAt       time.Time
+	LastSuccessAt       time.Time
+	CooldownUntil       time.Time
+}
+
+// HealthReporter is implemented by clients that track the health of their providers.
+type HealthReporter interface {
+	ProviderStatuses() []ProviderStatus
 }
@@ -0,0 +1,104 @@
+package instagram
+
+import (
+	"encoding/base64"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// instagramEpochMs is the custom epoch of Instagram's sharded media IDs, in Unix milliseconds.
+const instagramEpochMs = 1314220021721
+
+// TimeFromMediaID decodes the creation time embedded in an Instagram media ID (pk).
+// The upper 41 bits of the ID are milliseconds since Instagram's epoch.
+func TimeFromMediaID(mediaID string) (time.Time, bool) {
+	id, err := strconv.ParseUint(mediaID, 10, 64)
+	if err != nil || id == 0 {
+		return time.Time{}, false
+	}
+
+	ms := int64(id>>23) + instagramEpochMs
+	t := time.UnixMilli(ms)
+	if t.Before(time.UnixMilli(instagramEpochMs)) || t.After(time.Now().Add(24*time.Hour)) {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// MediaIDFromURL extracts the media ID from the ig_cache_key parameter of an Instagram CDN URL.
+// The parameter is looked up on the URL itself and on a CDN URL nested in its "uri" parameter,
+// as used by the scraper site's download links.
+func MediaIDFromURL(rawURL string) (string, bool) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "", false
+	}
+
+	query := parsed.Query()
+	if nested := query.Get("uri"); nested != "" {
+		if id, ok := MediaIDFromURL(nested); ok {
+			return id, true
+		}
+	}
+
+	cacheKey := query.Get("ig_cache_key")
+	if cacheKey == "" {
+		return "", false
+	}
+
+	// The key looks like "<base64 of media id>.<variant>".
+	encoded, _, _ := strings.Cut(cacheKey, ".")
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", false
+	}
+
+	id, _, _ := strings.Cut(string(decoded), "_")
+	if _, err := strconv.ParseUint(id, 10, 64); err != nil {
+		return "", false
+	}
+	return id, true
+}
+
+// shortcodeAlphabet is the URL-safe base64 alphabet Instagram uses to encode media IDs as shortcodes.
+const shortcodeAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+
+var permalinkRegex = regexp.MustCompile(`instagram\.com/(?:[A-Za-z0-9_.]+/)?(p|reel|reels|tv)/([A-Za-z0-9_-]+)`)
+
+// ShortcodeFromMediaID converts a numeric media ID (pk) to the shortcode used in permalinks.
+func ShortcodeFromMediaID(mediaID string) (string, bool) {
+	id, err := strconv.ParseUint(mediaID, 10, 64)
+	if err != nil || id == 0 {
+		return "", false
+	}
+
+	var buf [11]byte
+	i := len(buf)
+	for id > 0 {
+		i--
+		buf[i] = shortcodeAlphabet[id%64]
+		id /= 64
+	}
+	return string(buf[i:]), true
+}
+
+// ShortcodeFromURL extracts the shortcode and whether the link points to a reel from an
+// Instagram post, reel or IGTV permalink.
+func ShortcodeFromURL(rawURL string) (shortcode string, isReel bool, ok bool) {
+	match := permalinkRegex.FindStringSubmatch(rawURL)
+	if match == nil {
+		return "", false, false
+	}
+	return match[2], match[1] == "reel" || match[1] == "reels", true
+}
+
+// Permalink builds the canonical Instagram URL of a post or reel.
+func Permalink(shortcode string, isReel bool) string {
+	if isReel {
+		return "https://www.instagram.com/reel/" + shortcode + "/"
+	}
+	return "https://www.instagram.com/p/" + shortcode + "/"
+}
@@ -0,0 +1,230 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: instagram.go
+//
+// Generated by this command:
+//
+//	mockgen -source=instagram.go -destination=mocks/mock.go
+//
+
+// Package mock_instagram is a generated GoMock package.
+package mock_instagram
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	instagram "github.com/orgball2608/insta-parser-telegram-bot/internal/instagram"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockClient is a mock of Client interface.
+type MockClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientMockRecorder
+	isgomock struct{}
+}
+
+// MockClientMockRecorder is the mock recorder for MockClient.
+type MockClientMockRecorder struct {
+	mock *MockClient
+}
+
+// NewMockClient creates a new mock instance.
+func NewMockClient(ctrl *gomock.Controller) *MockClient {
+	mock := &MockClient{ctrl: ctrl}
+	mock.recorder = &MockClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClient) EXPECT() *MockClientMockRecorder {
+	return m.recorder
+}
+
+// GetHighlightAlbumPreviews mocks base method.
+func (m *MockClient) GetHighlightAlbumPreviews(ctx context.Context, userName string) ([]domain.HighlightAlbumPreview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHighlightAlbumPreviews", ctx, userName)
+	ret0, _ := ret[0].([]domain.HighlightAlbumPreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHighlightAlbumPreviews indicates an expected call of GetHighlightAlbumPreviews.
+func (mr *MockClientMockRecorder) GetHighlightAlbumPreviews(ctx, userName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHighlightAlbumPreviews", reflect.TypeOf((*MockClient)(nil).GetHighlightAlbumPreviews), ctx, userName)
+}
+
+// GetProfileSnapshot mocks base method.
+func (m *MockClient) GetProfileSnapshot(ctx context.Context, userName string) (*domain.AccountSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfileSnapshot", ctx, userName)
+	ret0, _ := ret[0].(*domain.AccountSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfileSnapshot indicates an expected call of GetProfileSnapshot.
+func (mr *MockClientMockRecorder) GetProfileSnapshot(ctx, userName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfileSnapshot", reflect.TypeOf((*MockClient)(nil).GetProfileSnapshot), ctx, userName)
+}
+
+// GetSingleHighlightAlbum mocks base method.
+func (m *MockClient) GetSingleHighlightAlbum(ctx context.Context, userName, albumID string) (*domain.HighlightReel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSingleHighlightAlbum", ctx, userName, albumID)
+	ret0, _ := ret[0].(*domain.HighlightReel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSingleHighlightAlbum indicates an expected call of GetSingleHighlightAlbum.
+func (mr *MockClientMockRecorder) GetSingleHighlightAlbum(ctx, userName, albumID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSingleHighlightAlbum", reflect.TypeOf((*MockClient)(nil).GetSingleHighlightAlbum), ctx, userName, albumID)
+}
+
+// GetUserHighlights mocks base method.
+func (m *MockClient) GetUserHighlights(ctx context.Context, userName string, processorFunc instagram.HighlightReelProcessorFunc) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserHighlights", ctx, userName, processorFunc)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetUserHighlights indicates an expected call of GetUserHighlights.
+func (mr *MockClientMockRecorder) GetUserHighlights(ctx, userName, processorFunc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserHighlights", reflect.TypeOf((*MockClient)(nil).GetUserHighlights), ctx, userName, processorFunc)
+}
+
+// GetUserPost mocks base method.
+func (m *MockClient) GetUserPost(ctx context.Context, postURL string) (*domain.PostItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserPost", ctx, postURL)
+	ret0, _ := ret[0].(*domain.PostItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserPost indicates an expected call of GetUserPost.
+func (mr *MockClientMockRecorder) GetUserPost(ctx, postURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPost", reflect.TypeOf((*MockClient)(nil).GetUserPost), ctx, postURL)
+}
+
+// GetUserPosts mocks base method.
+func (m *MockClient) GetUserPosts(ctx context.Context, userName string) ([]domain.PostItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserPosts", ctx, userName)
+	ret0, _ := ret[0].([]domain.PostItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserPosts indicates an expected call of GetUserPosts.
+func (mr *MockClientMockRecorder) GetUserPosts(ctx, userName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPosts", reflect.TypeOf((*MockClient)(nil).GetUserPosts), ctx, userName)
+}
+
+// GetUserProfile mocks base method.
+func (m *MockClient) GetUserProfile(ctx context.Context, userName string) (*domain.UserProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserProfile", ctx, userName)
+	ret0, _ := ret[0].(*domain.UserProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserProfile indicates an expected call of GetUserProfile.
+func (mr *MockClientMockRecorder) GetUserProfile(ctx, userName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserProfile", reflect.TypeOf((*MockClient)(nil).GetUserProfile), ctx, userName)
+}
+
+// GetUserReel mocks base method.
+func (m *MockClient) GetUserReel(ctx context.Context, reelURL string) (*domain.PostItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserReel", ctx, reelURL)
+	ret0, _ := ret[0].(*domain.PostItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserReel indicates an expected call of GetUserReel.
+func (mr *MockClientMockRecorder) GetUserReel(ctx, reelURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserReel", reflect.TypeOf((*MockClient)(nil).GetUserReel), ctx, reelURL)
+}
+
+// GetUserReels mocks base method.
+func (m *MockClient) GetUserReels(ctx context.Context, userName string) ([]domain.PostItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserReels", ctx, userName)
+	ret0, _ := ret[0].([]domain.PostItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserReels indicates an expected call of GetUserReels.
+func (mr *MockClientMockRecorder) GetUserReels(ctx, userName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserReels", reflect.TypeOf((*MockClient)(nil).GetUserReels), ctx, userName)
+}
+
+// GetUserStories mocks base method.
+func (m *MockClient) GetUserStories(ctx context.Context, userName string) ([]domain.StoryItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserStories", ctx, userName)
+	ret0, _ := ret[0].([]domain.StoryItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserStories indicates an expected call of GetUserStories.
+func (mr *MockClientMockRecorder) GetUserStories(ctx, userName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserStories", reflect.TypeOf((*MockClient)(nil).GetUserStories), ctx, userName)
+}
+
+// MockHealthReporter is a mock of HealthReporter interface.
+type MockHealthReporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockHealthReporterMockRecorder
+	isgomock struct{}
+}
+
+// MockHealthReporterMockRecorder is the mock recorder for MockHealthReporter.
+type MockHealthReporterMockRecorder struct {
+	mock *MockHealthReporter
+}
+
+// NewMockHealthReporter creates a new mock instance.
+func NewMockHealthReporter(ctrl *gomock.Controller) *MockHealthReporter {
+	mock := &MockHealthReporter{ctrl: ctrl}
+	mock.recorder = &MockHealthReporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHealthReporter) EXPECT() *MockHealthReporterMockRecorder {
+	return m.recorder
+}
+
+// ProviderStatuses mocks base method.
+func (m *MockHealthReporter) ProviderStatuses() []instagram.ProviderStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProviderStatuses")
+	ret0, _ := ret[0].([]instagram.ProviderStatus)
+	return ret0
+}
+
+// ProviderStatuses indicates an expected call of ProviderStatuses.
+func (mr *MockHealthReporterMockRecorder) ProviderStatuses() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProviderStatuses", reflect.TypeOf((*MockHealthReporter)(nil).ProviderStatuses))
+}
@@ -0,0 +1,209 @@
+package scraperprofile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+)
+
+// SupportedVersion is the profile format understood by this build.
+const SupportedVersion = 1
+
+// Profile holds everything the browser scraper needs to know about the scraper site:
+// target URLs, selectors, tab XPaths, scroll limits and timeouts.
+type Profile struct {
+	Version   int       `json:"version"`
+	URLs      URLs      `json:"urls"`
+	Selectors Selectors `json:"selectors"`
+	Tabs      Tabs      `json:"tabs"`
+	Scroll    Scroll    `json:"scroll"`
+	Timeouts  Timeouts  `json:"timeouts"`
+}
+
+type URLs struct {
+	// Profile is the page used to search for an account and browse its tabs.
+	Profile string `json:"profile"`
+	// Media is the page used to resolve a single post or reel URL.
+	Media string `json:"media"`
+}
+
+type Selectors struct {
+	SearchInput    string `json:"search_input"`
+	SearchButton   string `json:"search_button"`
+	ProfileResult  string `json:"profile_result"`
+	ErrorMessage   string `json:"error_message"`
+	CookieButton   string `json:"cookie_button"`
+	MediaList      string `json:"media_list"`
+	MediaItem      string `json:"media_item"`
+	DownloadButton string `json:"download_button"`
+	HighlightAlbum string `json:"highlight_album"`
+	HighlightTitle string `json:"highlight_title"`
+	HighlightCover string `json:"highlight_cover"`
+	MediaResult    string `json:"media_result"`
+	Caption        string `json:"caption"`
+	AuthorAvatar   string `json:"author_avatar"`
+	Likes          string `json:"likes"`
+	PostedAgo      string `json:"posted_ago"`
+	// MediaTime is the posting time shown on a listed story or highlight item, looked up inside
+	// MediaItem. It is optional; without it the time is derived from the media URL.
+	MediaTime string `json:"media_time"`
+	// Permalink matches links to the Instagram post inside a MediaItem of the posts tab. It is
+	// optional; without it the shortcode is decoded from the download link.
+	Permalink string `json:"permalink"`
+	// ResultItem wraps one media of a post or reel result, used to find a video's thumbnail.
+	// It is optional.
+	ResultItem string `json:"result_item"`
+	// Profile* are looked up inside ProfileResult to read the account's metadata.
+	ProfileAvatar   string `json:"profile_avatar"`
+	ProfileFullName string `json:"profile_full_name"`
+	ProfileBio      string `json:"profile_bio"`
+	// ProfileStat matches each of the post, follower and following counters; they are told
+	// apart by their label. ProfileStatValue is the number inside one counter.
+	ProfileStat      string `json:"profile_stat"`
+	ProfileStatValue string `json:"profile_stat_value"`
+	// ProfileVerified is the verification badge. It is optional.
+	ProfileVerified string `json:"profile_verified"`
+}
+
+type Tabs struct {
+	Stories    string `json:"stories"`
+	Highlights string `json:"highlights"`
+	Posts      string `json:"posts"`
+	// Reels is optional; without it the browser scraper cannot list reels.
+	Reels string `json:"reels"`
+}
+
+type Scroll struct {
+	MaxAttempts   int `json:"max_attempts"`
+	DelayMs       int `json:"delay_ms"`
+	DelayJitterMs int `json:"delay_jitter_ms"`
+	MaxPosts      int `json:"max_posts"`
+}
+
+// Timeouts are expressed in milliseconds, the unit Playwright expects.
+type Timeouts struct {
+	NavigationMs    float64 `json:"navigation_ms"`
+	TypeMs          float64 `json:"type_ms"`
+	ClickMs         float64 `json:"click_ms"`
+	SearchResultsMs float64 `json:"search_results_ms"`
+	InputVisibleMs  float64 `json:"input_visible_ms"`
+	AlbumListMs     float64 `json:"album_list_ms"`
+	MediaListMs     float64 `json:"media_list_ms"`
+	PostListMs      float64 `json:"post_list_ms"`
+	TabSettleMs     float64 `json:"tab_settle_ms"`
+}
+
+// ScrollDelay is the pause between two scroll attempts.
+func (s Scroll) ScrollDelay(jitter int) time.Duration {
+	return time.Duration(s.DelayMs+jitter) * time.Millisecond
+}
+
+// field pairs a profile key with its value for validation.
+type field[T any] struct {
+	name  string
+	value T
+}
+
+// Load reads and validates a profile file.
+func Load(path string) (*Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read scraper profile %s: %w", path, err)
+	}
+
+	var p Profile
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("could not parse scraper profile %s: %w", path, err)
+	}
+
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid scraper profile %s: %w", path, err)
+	}
+
+	return &p, nil
+}
+
+// Validate reports every missing or malformed field at once.
+func (p *Profile) Validate() error {
+	var errs []error
+
+	if p.Version != SupportedVersion {
+		errs = append(errs, fmt.Errorf("unsupported version %d, expected %d", p.Version, SupportedVersion))
+	}
+
+	for _, f := range []field[string]{
+		{"urls.profile", p.URLs.Profile},
+		{"urls.media", p.URLs.Media},
+	} {
+		u, err := url.Parse(f.value)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			errs = append(errs, fmt.Errorf("%s must be an absolute URL, got %q", f.name, f.value))
+		}
+	}
+
+	for _, f := range []field[string]{
+		{"selectors.search_input", p.Selectors.SearchInput},
+		{"selectors.search_button", p.Selectors.SearchButton},
+		{"selectors.profile_result", p.Selectors.ProfileResult},
+		{"selectors.error_message", p.Selectors.ErrorMessage},
+		{"selectors.cookie_button", p.Selectors.CookieButton},
+		{"selectors.media_list", p.Selectors.MediaList},
+		{"selectors.media_item", p.Selectors.MediaItem},
+		{"selectors.download_button", p.Selectors.DownloadButton},
+		{"selectors.highlight_album", p.Selectors.HighlightAlbum},
+		{"selectors.highlight_title", p.Selectors.HighlightTitle},
+		{"selectors.highlight_cover", p.Selectors.HighlightCover},
+		{"selectors.media_result", p.Selectors.MediaResult},
+		{"selectors.caption", p.Selectors.Caption},
+		{"selectors.author_avatar", p.Selectors.AuthorAvatar},
+		{"selectors.likes", p.Selectors.Likes},
+		{"selectors.posted_ago", p.Selectors.PostedAgo},
+		{"selectors.profile_avatar", p.Selectors.ProfileAvatar},
+		{"selectors.profile_full_name", p.Selectors.ProfileFullName},
+		{"selectors.profile_bio", p.Selectors.ProfileBio},
+		{"selectors.profile_stat", p.Selectors.ProfileStat},
+		{"selectors.profile_stat_value", p.Selectors.ProfileStatValue},
+		{"tabs.stories", p.Tabs.Stories},
+		{"tabs.highlights", p.Tabs.Highlights},
+		{"tabs.posts", p.Tabs.Posts},
+	} {
+		if f.value == "" {
+			errs = append(errs, fmt.Errorf("%s must not be empty", f.name))
+		}
+	}
+
+	for _, f := range []field[int]{
+		{"scroll.max_attempts", p.Scroll.MaxAttempts},
+		{"scroll.max_posts", p.Scroll.MaxPosts},
+	} {
+		if f.value <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive", f.name))
+		}
+	}
+	if p.Scroll.DelayMs < 0 || p.Scroll.DelayJitterMs < 0 {
+		errs = append(errs, errors.New("scroll delays must not be negative"))
+	}
+
+	for _, f := range []field[float64]{
+		{"timeouts.navigation_ms", p.Timeouts.NavigationMs},
+		{"timeouts.type_ms", p.Timeouts.TypeMs},
+		{"timeouts.click_ms", p.Timeouts.ClickMs},
+		{"timeouts.search_results_ms", p.Timeouts.SearchResultsMs},
+		{"timeouts.input_visible_ms", p.Timeouts.InputVisibleMs},
+		{"timeouts.album_list_ms", p.Timeouts.AlbumListMs},
+		{"timeouts.media_list_ms", p.Timeouts.MediaListMs},
+		{"timeouts.post_list_ms", p.Timeouts.PostListMs},
+	} {
+		if f.value <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive", f.name))
+		}
+	}
+	if p.Timeouts.TabSettleMs < 0 {
+		errs = append(errs, errors.New("timeouts.tab_settle_ms must not be negative"))
+	}
+
+	return errors.Join(errs...)
+}
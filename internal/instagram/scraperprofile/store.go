@@ -0,0 +1,112 @@
+package scraperprofile
+
+import (
+	"context"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/config"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/logger"
+	"go.uber.org/fx"
+)
+
+// Store holds the active profile and swaps it atomically on reload.
+// A profile that fails validation never replaces the active one.
+type Store struct {
+	path   string
+	logger logger.Logger
+
+	current atomic.Pointer[Profile]
+
+	mu      sync.Mutex
+	modTime time.Time
+}
+
+// NewStore loads the profile at startup and, when an interval is configured,
+// reloads it whenever the file changes on disk.
+func NewStore(lc fx.Lifecycle, cfg *config.Config, log logger.Logger) (*Store, error) {
+	s := &Store{
+		path:   cfg.Parser.ProfilePath,
+		logger: log.WithComponent("ScraperProfile"),
+	}
+
+	if _, err := s.Reload(); err != nil {
+		return nil, err
+	}
+
+	interval := cfg.Parser.ProfileReloadInterval
+	if interval <= 0 {
+		return s, nil
+	}
+
+	watchCtx, cancel := context.WithCancel(context.Background())
+	lc.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			go s.watch(watchCtx, interval)
+			return nil
+		},
+		OnStop: func(_ context.Context) error {
+			cancel()
+			return nil
+		},
+	})
+
+	return s, nil
+}
+
+// Current returns the active profile. Callers should read it once per scrape
+// so a reload never mixes two profiles within one page session.
+func (s *Store) Current() *Profile {
+	return s.current.Load()
+}
+
+// Reload reads the profile file again and activates it if it is valid.
+func (s *Store) Reload() (*Profile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := Load(s.path)
+	if err != nil {
+		s.logger.Error("Rejected scraper profile", "path", s.path, "error", err)
+		return nil, err
+	}
+
+	s.current.Store(p)
+	s.modTime = info.ModTime()
+	s.logger.Info("Scraper profile loaded", "path", s.path, "version", p.Version)
+	return p, nil
+}
+
+func (s *Store) watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			info, err := os.Stat(s.path)
+			if err != nil {
+				s.logger.Warn("Could not stat scraper profile", "path", s.path, "error", err)
+				continue
+			}
+
+			s.mu.Lock()
+			changed := !info.ModTime().Equal(s.modTime)
+			s.mu.Unlock()
+
+			if changed {
+				s.logger.Info("Scraper profile changed on disk, reloading", "path", s.path)
+				_, _ = s.Reload()
+			}
+		}
+	}
+}
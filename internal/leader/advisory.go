@@ -0,0 +1,136 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/config"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/logger"
+)
+
+// AdvisoryLockElector elects the replica holding a Postgres session advisory lock. The lock
+// lives as long as the connection that took it, so the leader keeps that connection open and
+// pings it; if the leader dies or loses the database, Postgres releases the lock and a standby
+// takes it.
+type AdvisoryLockElector struct {
+	pool     *pgxpool.Pool
+	logger   logger.Logger
+	lockID   int64
+	interval time.Duration
+	leader   atomic.Bool
+}
+
+func NewAdvisoryLockElector(pool *pgxpool.Pool, cfg *config.Config, logger logger.Logger) *AdvisoryLockElector {
+	return &AdvisoryLockElector{
+		pool:     pool,
+		logger:   logger.WithComponent("LeaderElector"),
+		lockID:   cfg.App.LeaderLockID,
+		interval: cfg.App.LeaderCheckInterval,
+	}
+}
+
+var _ Elector = (*AdvisoryLockElector)(nil)
+
+func (e *AdvisoryLockElector) Role() string {
+	if e.leader.Load() {
+		return RoleLeader
+	}
+	return RoleStandby
+}
+
+func (e *AdvisoryLockElector) Run(ctx context.Context, lead func(ctx context.Context) error) error {
+	e.logger.Info("Campaigning for leadership", "lock_id", e.lockID)
+
+	for {
+		conn, err := e.tryAcquire(ctx)
+		if err != nil && ctx.Err() == nil {
+			e.logger.Error("Failed to campaign for leadership", "error", err)
+		}
+
+		if conn != nil {
+			if err := e.lead(ctx, conn, lead); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(e.interval):
+		}
+	}
+}
+
+// tryAcquire takes the lock on a connection of its own, returning nil when another replica
+// holds it.
+func (e *AdvisoryLockElector) tryAcquire(ctx context.Context) (*pgx.Conn, error) {
+	pooled, err := e.pool.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	// The connection leaves the pool, so the lock is never handed to another caller
+	conn := pooled.Hijack()
+
+	var acquired bool
+	if err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", e.lockID).Scan(&acquired); err != nil {
+		_ = conn.Close(context.Background())
+		return nil, fmt.Errorf("failed to try advisory lock: %w", err)
+	}
+
+	if !acquired {
+		_ = conn.Close(context.Background())
+		return nil, nil
+	}
+
+	return conn, nil
+}
+
+// lead runs lead while the lock connection stays healthy and releases the lock afterwards.
+func (e *AdvisoryLockElector) lead(ctx context.Context, conn *pgx.Conn, lead func(ctx context.Context) error) error {
+	// Closing the session releases the lock
+	defer conn.Close(context.Background())
+
+	e.leader.Store(true)
+	defer e.leader.Store(false)
+	e.logger.Info("Became leader")
+
+	leaderCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- lead(leaderCtx)
+	}()
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case err := <-done:
+			if err != nil && !errors.Is(err, context.Canceled) {
+				return err
+			}
+			return nil
+		case <-ctx.Done():
+			cancel()
+			<-done
+			return nil
+		case <-ticker.C:
+			pingCtx, pingCancel := context.WithTimeout(ctx, e.interval)
+			err := conn.Ping(pingCtx)
+			pingCancel()
+			if err != nil {
+				e.logger.Warn("Lost leadership, stopping leader services", "error", err)
+				cancel()
+				<-done
+				return nil
+			}
+		}
+	}
+}
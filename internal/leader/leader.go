@@ -0,0 +1,21 @@
+package leader
+
+import "context"
+
+// Roles of a replica
+const (
+	RoleLeader  = "leader"
+	RoleStandby = "standby"
+)
+
+// Elector decides which replica runs the services that must not run twice: the schedulers and
+// the Telegram long polling.
+type Elector interface {
+	// Role returns RoleLeader while this replica holds leadership and RoleStandby otherwise
+	Role() string
+
+	// Run campaigns for leadership until ctx is done. Each time leadership is won, lead is
+	// called with a context that is cancelled when it is lost. An error returned by lead,
+	// other than the cancellation, stops Run and is returned.
+	Run(ctx context.Context, lead func(ctx context.Context) error) error
+}
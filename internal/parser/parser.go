@@ -7,12 +7,16 @@ import (
 )
 
 type Client interface {
-	ParseUserStories(ctx context.Context, username string) error
 	ScheduleParseStories(ctx context.Context) error
-	ProcessStories(stories []domain.StoryItem) error
 	SaveHighlight(highlight domain.Highlights) error
 	SaveCurrentStory(currentStory domain.CurrentStory) error
 	ClearCurrentStories(username string) error
 	ScheduleDatabaseCleanup(ctx context.Context) error
 	SchedulePostChecking(ctx context.Context) error
+	ScheduleReelChecking(ctx context.Context) error
+	ScheduleProfileChecking(ctx context.Context) error
+	ScheduleHighlightChecking(ctx context.Context) error
+	ScheduleCanary(ctx context.Context) error
+	ScheduleOutboxDispatch(ctx context.Context) error
+	RunScrapeWorkers(ctx context.Context) error
 }
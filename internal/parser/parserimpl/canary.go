@@ -0,0 +1,265 @@
+package paserimpl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/scheduler"
+)
+
+// errCanaryStop stops GetUserHighlights after the first album has been processed.
+var errCanaryStop = errors.New("canary: stop after first highlight album")
+
+// canaryResult is the outcome of a single canary check against one provider.
+type canaryResult struct {
+	Provider   string
+	Account    string
+	Method     string
+	Step       string
+	Err        error
+	Screenshot string
+}
+
+func (r canaryResult) key() string {
+	return r.Provider + "/" + r.Account + "/" + r.Method
+}
+
+// ScheduleCanary periodically scrapes the configured canary accounts through every provider
+// and alerts the admin chat when a check starts failing or recovers.
+func (p *ParserImpl) ScheduleCanary(ctx context.Context) error {
+	accounts := p.Config.Parser.CanaryAccounts
+	if len(accounts) == 0 {
+		p.Logger.Info("No canary accounts configured, scraper canary disabled")
+		return nil
+	}
+
+	// failing holds the failed step of every check that failed on the previous run.
+	failing := make(map[string]string)
+
+	return p.Scheduler.Schedule(ctx, scheduler.Job{
+		Name:       "canary",
+		Definition: gocron.CronJob(p.Config.Parser.CanaryInterval, false),
+		Options:    []gocron.JobOption{gocron.WithStartAt(gocron.WithStartImmediately())},
+		Task: func(ctx context.Context) error {
+			runCtx, cancel := context.WithTimeout(ctx, 30*time.Minute)
+			defer cancel()
+
+			p.Logger.Info("Running scraper canary", "accounts", len(accounts), "providers", len(p.Providers))
+			var failed int
+			for _, provider := range p.Providers {
+				for _, account := range accounts {
+					for _, result := range p.runCanaryChecks(runCtx, provider, account) {
+						if result.Err != nil {
+							failed++
+						}
+						p.reportCanaryResult(failing, result)
+					}
+				}
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%d canary checks failed", failed)
+			}
+			return nil
+		},
+	})
+}
+
+// runCanaryChecks exercises every instagram.Client method of a provider for one account. The
+// calls are pinned to the provider but still wait for the shared scraper budget.
+func (p *ParserImpl) runCanaryChecks(ctx context.Context, provider instagram.Provider, account string) []canaryResult {
+	ctx = instagram.WithProvider(ctx, provider.Name)
+	client := p.Instagram
+	var results []canaryResult
+
+	check := func(method string, fn func() error) {
+		if ctx.Err() != nil {
+			return
+		}
+		err := fn()
+		if errors.Is(err, instagram.ErrNotSupported) {
+			return
+		}
+		results = append(results, newCanaryResult(provider.Name, account, method, err))
+	}
+
+	check("GetUserProfile", func() error {
+		profile, err := client.GetUserProfile(ctx, account)
+		if err == nil && (profile == nil || profile.AvatarURL == "") {
+			return emptyResultError(instagram.StepProfileHeader, "profile has no avatar")
+		}
+		return err
+	})
+
+	check("GetUserStories", func() error {
+		// An account without live stories is fine; only a broken page is reported.
+		_, err := client.GetUserStories(ctx, account)
+		var stepErr *instagram.StepError
+		if errors.As(err, &stepErr) && stepErr.Step == instagram.StepMediaList {
+			return nil
+		}
+		return err
+	})
+
+	var albums []domain.HighlightAlbumPreview
+	check("GetHighlightAlbumPreviews", func() error {
+		var err error
+		albums, err = client.GetHighlightAlbumPreviews(ctx, account)
+		if err == nil && len(albums) == 0 {
+			return emptyResultError(instagram.StepHighlightAlbums, "no highlight albums returned")
+		}
+		return err
+	})
+
+	if len(albums) > 0 {
+		check("GetSingleHighlightAlbum", func() error {
+			reel, err := client.GetSingleHighlightAlbum(ctx, account, albums[0].ID)
+			if err == nil && (reel == nil || len(reel.Items) == 0) {
+				return emptyResultError(instagram.StepMediaList, fmt.Sprintf("highlight album %q is empty", albums[0].Title))
+			}
+			return err
+		})
+	}
+
+	check("GetUserHighlights", func() error {
+		var items int
+		err := client.GetUserHighlights(ctx, account, func(reel domain.HighlightReel) error {
+			items = len(reel.Items)
+			return errCanaryStop
+		})
+		if errors.Is(err, errCanaryStop) {
+			err = nil
+		}
+		if err == nil && items == 0 {
+			return emptyResultError(instagram.StepMediaList, "first highlight album is empty")
+		}
+		return err
+	})
+
+	var posts []domain.PostItem
+	check("GetUserPosts", func() error {
+		var err error
+		posts, err = client.GetUserPosts(ctx, account)
+		if err == nil && len(posts) == 0 {
+			return emptyResultError(instagram.StepPostsTab, "no posts returned")
+		}
+		return err
+	})
+
+	check("GetUserReels", func() error {
+		// Accounts without reels are fine; only a broken tab is reported.
+		_, err := client.GetUserReels(ctx, account)
+		return err
+	})
+
+	if len(posts) > 0 {
+		check("GetUserPost", func() error {
+			post, err := client.GetUserPost(ctx, posts[0].URL)
+			if err == nil && (post == nil || len(post.Media) == 0) {
+				return emptyResultError(instagram.StepDownloadButtons, "post has no media")
+			}
+			return err
+		})
+	}
+
+	check("GetProfileSnapshot", func() error {
+		snapshot, err := client.GetProfileSnapshot(ctx, account)
+		if err == nil && (snapshot == nil || len(snapshot.Posts) == 0) {
+			return emptyResultError(instagram.StepPostsTab, "snapshot has no posts")
+		}
+		return err
+	})
+
+	if reelURL := p.Config.Parser.CanaryReelURL; reelURL != "" {
+		check("GetUserReel", func() error {
+			reel, err := client.GetUserReel(ctx, reelURL)
+			if err == nil && (reel == nil || len(reel.Media) == 0) {
+				return emptyResultError(instagram.StepDownloadButtons, "reel has no media")
+			}
+			return err
+		})
+	}
+
+	return results
+}
+
+// emptyResultError reports a scrape that succeeded but returned nothing, which usually means
+// a selector no longer matches.
+func emptyResultError(step, msg string) error {
+	return &instagram.StepError{Step: step, Err: fmt.Errorf("%s: %w", msg, instagram.ErrEmptyResult)}
+}
+
+func newCanaryResult(provider, account, method string, err error) canaryResult {
+	result := canaryResult{Provider: provider, Account: account, Method: method, Err: err}
+	if err == nil {
+		return result
+	}
+
+	var stepErr *instagram.StepError
+	if errors.As(err, &stepErr) {
+		result.Step = stepErr.Step
+		result.Screenshot = stepErr.Screenshot
+	} else {
+		result.Step = "unknown"
+	}
+	return result
+}
+
+// reportCanaryResult logs a check outcome and alerts the admin chat when its state changed
+// since the previous run, so a persistent breakage is reported once rather than every run.
+func (p *ParserImpl) reportCanaryResult(failing map[string]string, result canaryResult) {
+	key := result.key()
+	previousStep, wasFailing := failing[key]
+
+	if result.Err == nil {
+		if wasFailing {
+			delete(failing, key)
+			p.Logger.Info("Canary check recovered", "provider", result.Provider, "account", result.Account, "method", result.Method)
+			p.alertAdmin(fmt.Sprintf("✅ Scraper canary recovered\n\nProvider: %s\nAccount: %s\nMethod: %s",
+				result.Provider, result.Account, result.Method), "")
+		}
+		return
+	}
+
+	p.Logger.Warn("Canary check failed",
+		"provider", result.Provider, "account", result.Account, "method", result.Method,
+		"step", result.Step, "error", result.Err)
+
+	failing[key] = result.Step
+	if wasFailing && previousStep == result.Step {
+		return
+	}
+
+	p.alertAdmin(fmt.Sprintf("🚨 Scraper canary failed\n\nProvider: %s\nAccount: %s\nMethod: %s\nFailed step: %s\nError: %v",
+		result.Provider, result.Account, result.Method, result.Step, result.Err), result.Screenshot)
+}
+
+// alertAdmin sends text to the admin chat, attaching the screenshot when one was saved.
+func (p *ParserImpl) alertAdmin(text, screenshot string) {
+	chatID := p.Config.Telegram.User
+	if chatID == 0 {
+		p.Logger.Warn("Admin chat is not configured, dropping canary alert")
+		return
+	}
+
+	if _, err := p.Telegram.SendMessage(chatID, text); err != nil {
+		p.Logger.Error("Failed to send canary alert", "error", err)
+		return
+	}
+
+	if screenshot == "" {
+		return
+	}
+
+	photo := tgbotapi.NewPhoto(chatID, tgbotapi.FilePath(screenshot))
+	photo.Caption = "Page at the failing step"
+	if _, err := p.Telegram.Send(photo); err != nil {
+		p.Logger.Error("Failed to send canary screenshot", "path", screenshot, "error", err)
+	}
+}
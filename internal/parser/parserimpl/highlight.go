@@ -0,0 +1,163 @@
+package paserimpl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/highlights"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/scheduler"
+)
+
+// ScheduleHighlightChecking sets up a job that queues a walk of the highlight albums of every
+// account with highlight subscriptions.
+func (p *ParserImpl) ScheduleHighlightChecking(ctx context.Context) error {
+	interval := p.Config.Parser.HighlightCheckInterval
+	p.Logger.Info("Setting up highlight checking scheduler", "interval", interval)
+
+	return p.Scheduler.Schedule(ctx, scheduler.Job{
+		Name:       "highlights",
+		Definition: gocron.CronJob(interval, false),
+		Task: func(ctx context.Context) error {
+			p.Logger.Info("Running scheduled highlight check")
+
+			checkCtx, cancel := context.WithTimeout(ctx, time.Minute)
+			defer cancel()
+
+			usernames, err := p.SubscriptionRepo.GetAllUniqueUsernamesByType(checkCtx, domain.SubscriptionTypeHighlight)
+			if err != nil {
+				return fmt.Errorf("failed to get usernames for highlight checking: %w", err)
+			}
+
+			// The checks themselves run on the scrape workers
+			p.enqueueScrapeJobs(checkCtx, domain.SubscriptionTypeHighlight, usernames)
+			return nil
+		},
+	})
+}
+
+// highlightUpdate is the outbox payload for the new items of one highlight album.
+type highlightUpdate struct {
+	Username   string             `json:"username"`
+	AlbumTitle string             `json:"album_title"`
+	IsNewAlbum bool               `json:"is_new_album"`
+	Items      []domain.StoryItem `json:"items"`
+}
+
+// checkNewHighlightsForUser walks the user's highlight albums and queues the items that were
+// not seen before, one message group per album. The first walk of an account only records its
+// items so subscribers are not flooded with its whole highlight history.
+func (p *ParserImpl) checkNewHighlightsForUser(ctx context.Context, username string) error {
+	known, err := p.HighlightsRepo.GetTrackedItemIDs(ctx, username)
+	if err != nil {
+		return fmt.Errorf("failed to get tracked highlight items of %s: %w", username, err)
+	}
+	baseline := len(known) == 0
+
+	var subscribers []int64
+	if !baseline {
+		subscribers, err = p.SubscriptionRepo.GetSubscribersForUserByType(ctx, username, domain.SubscriptionTypeHighlight)
+		if err != nil {
+			return fmt.Errorf("failed to get subscribers of %s: %w", username, err)
+		}
+	}
+
+	var recorded int
+	err = p.Instagram.GetUserHighlights(ctx, username, func(reel domain.HighlightReel) error {
+		var newItems []domain.StoryItem
+
+		// The album's items are recorded and its notifications queued together, so a crash or
+		// a Telegram outage can't leave them marked as seen but never sent
+		err := p.Transactor.WithinTx(ctx, func(ctx context.Context) error {
+			newItems = nil
+			for _, item := range reel.Items {
+				itemID := highlightItemID(item)
+				if itemID == "" || known[itemID] {
+					continue
+				}
+
+				// An item is known under any album, so re-covering or renaming an album does not
+				// make its items look new.
+				err := p.HighlightsRepo.Create(ctx, domain.Highlights{
+					UserName: username,
+					MediaURL: item.MediaURL,
+					AlbumID:  reel.ID,
+					ItemID:   itemID,
+				})
+				if errors.Is(err, highlights.ErrAlreadyExists) {
+					continue
+				}
+				if err != nil {
+					return fmt.Errorf("failed to record highlight item %s: %w", itemID, err)
+				}
+				newItems = append(newItems, item)
+			}
+
+			if baseline || len(newItems) == 0 {
+				return nil
+			}
+
+			update := highlightUpdate{
+				Username:   username,
+				AlbumTitle: reel.Title,
+				IsNewAlbum: len(newItems) == len(reel.Items),
+				Items:      newItems,
+			}
+			// The first new item identifies this batch, since an item is only ever new once
+			return p.enqueueNotifications(ctx, domain.SubscriptionTypeHighlight, highlightItemID(newItems[0]), update, subscribers)
+		})
+		if err != nil {
+			return fmt.Errorf("album %q: %w", reel.Title, err)
+		}
+
+		for _, item := range newItems {
+			known[highlightItemID(item)] = true
+		}
+		recorded += len(newItems)
+		if !baseline && len(newItems) > 0 {
+			p.Logger.Info("Queued new highlight items for subscribers", "username", username, "album", reel.Title, "count", len(newItems), "subscriberCount", len(subscribers))
+		}
+		return nil
+	})
+	if baseline {
+		p.Logger.Info("Recorded existing highlight items for newly tracked account", "username", username, "count", recorded)
+	}
+
+	// Albums queued before a failure stay recorded, so a retry only sends what is left
+	if err != nil && !errors.Is(err, instagram.ErrPrivateAccount) {
+		return fmt.Errorf("failed to walk highlights of %s: %w", username, err)
+	}
+
+	return nil
+}
+
+// sendHighlightUpdate sends the new items of one album to a chat as a single album.
+func (p *ParserImpl) sendHighlightUpdate(chatID int64, update highlightUpdate) error {
+	caption := fmt.Sprintf("🌟 %d new items in the highlight \"%s\" of @%s", len(update.Items), update.AlbumTitle, update.Username)
+	if update.IsNewAlbum {
+		caption = fmt.Sprintf("🌟 New highlight \"%s\" from @%s", update.AlbumTitle, update.Username)
+	}
+
+	media := make([]domain.MediaItem, 0, len(update.Items))
+	for _, item := range update.Items {
+		media = append(media, item.GetMedia())
+	}
+
+	return p.Telegram.SendMediaAlbum(chatID, media, caption)
+}
+
+// highlightItemID returns a stable ID for a highlight item: the scraper's item ID, or the media
+// ID embedded in its URL.
+func highlightItemID(item domain.StoryItem) string {
+	if item.ID != "" {
+		return item.ID
+	}
+	if mediaID, ok := instagram.MediaIDFromURL(item.MediaURL); ok {
+		return mediaID
+	}
+	return ""
+}
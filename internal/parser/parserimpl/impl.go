@@ -6,13 +6,21 @@ import (
 	"time"
 
 	"github.com/go-co-op/gocron/v2"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram"
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/parser"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/accountpoll"
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/currentstory"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/delivery"
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/highlights"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/outbox"
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/post"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/profilesnapshot"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/scrapejob"
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/story"
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/subscription"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/scheduler"
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/telegram"
 	"github.com/orgball2608/insta-parser-telegram-bot/pkg/config"
 	"github.com/orgball2608/insta-parser-telegram-bot/pkg/logger"
@@ -22,111 +30,132 @@ import (
 type Opts struct {
 	fx.In
 
-	Instagram        instagram.Client
-	Telegram         telegram.Client
-	StoryRepo        story.Repository
-	HighlightsRepo   highlights.Repository
-	CurrentStoryRepo currentstory.Repository
-	PostRepo         post.Repository
-	Logger           logger.Logger
-	Config           *config.Config
-	SubscriptionRepo subscription.Repository
+	Instagram           instagram.Client
+	Telegram            telegram.Client
+	StoryRepo           story.Repository
+	HighlightsRepo      highlights.Repository
+	CurrentStoryRepo    currentstory.Repository
+	PostRepo            post.Repository
+	ProfileSnapshotRepo profilesnapshot.Repository
+	DeliveryRepo        delivery.Repository
+	OutboxRepo          outbox.Repository
+	ScrapeJobRepo       scrapejob.Repository
+	AccountPollRepo     accountpoll.Repository
+	Transactor          repositories.Transactor
+	Logger              logger.Logger
+	Config              *config.Config
+	SubscriptionRepo    subscription.Repository
+	Providers           []instagram.Provider `group:"instagram_providers"`
+	Scheduler           scheduler.Scheduler
 }
 
 type ParserImpl struct {
-	Instagram        instagram.Client
-	Telegram         telegram.Client
-	StoryRepo        story.Repository
-	HighlightsRepo   highlights.Repository
-	CurrentStoryRepo currentstory.Repository
-	PostRepo         post.Repository
-	Logger           logger.Logger
-	Config           *config.Config
-	SubscriptionRepo subscription.Repository
-	Scheduler        gocron.Scheduler
+	Instagram           instagram.Client
+	Telegram            telegram.Client
+	StoryRepo           story.Repository
+	HighlightsRepo      highlights.Repository
+	CurrentStoryRepo    currentstory.Repository
+	PostRepo            post.Repository
+	ProfileSnapshotRepo profilesnapshot.Repository
+	DeliveryRepo        delivery.Repository
+	OutboxRepo          outbox.Repository
+	ScrapeJobRepo       scrapejob.Repository
+	AccountPollRepo     accountpoll.Repository
+	Transactor          repositories.Transactor
+	Logger              logger.Logger
+	Config              *config.Config
+	SubscriptionRepo    subscription.Repository
+	Providers           []instagram.Provider
+	// Scheduler owns the periodic jobs. They are scheduled with the leader context, so they
+	// stop when this replica loses leadership.
+	Scheduler scheduler.Scheduler
 }
 
 func New(opts Opts) *ParserImpl {
-	loc, err := time.LoadLocation("Asia/Ho_Chi_Minh")
-	if err != nil {
-		loc = time.Local
-		opts.Logger.Warn("Failed to load Asia/Ho_Chi_Minh timezone, using local timezone", "error", err)
-	}
-
-	scheduler, err := gocron.NewScheduler(gocron.WithLocation(loc))
-	if err != nil {
-		opts.Logger.Error("Failed to create scheduler", "error", err)
-	}
-
 	return &ParserImpl{
-		Instagram:        opts.Instagram,
-		Telegram:         opts.Telegram,
-		StoryRepo:        opts.StoryRepo,
-		HighlightsRepo:   opts.HighlightsRepo,
-		CurrentStoryRepo: opts.CurrentStoryRepo,
-		PostRepo:         opts.PostRepo,
-		Logger:           opts.Logger,
-		Config:           opts.Config,
-		SubscriptionRepo: opts.SubscriptionRepo,
-		Scheduler:        scheduler,
+		Instagram:           opts.Instagram,
+		Telegram:            opts.Telegram,
+		StoryRepo:           opts.StoryRepo,
+		HighlightsRepo:      opts.HighlightsRepo,
+		CurrentStoryRepo:    opts.CurrentStoryRepo,
+		PostRepo:            opts.PostRepo,
+		ProfileSnapshotRepo: opts.ProfileSnapshotRepo,
+		DeliveryRepo:        opts.DeliveryRepo,
+		OutboxRepo:          opts.OutboxRepo,
+		ScrapeJobRepo:       opts.ScrapeJobRepo,
+		AccountPollRepo:     opts.AccountPollRepo,
+		Transactor:          opts.Transactor,
+		Logger:              opts.Logger,
+		Config:              opts.Config,
+		SubscriptionRepo:    opts.SubscriptionRepo,
+		Providers:           opts.Providers,
+		Scheduler:           opts.Scheduler,
 	}
 }
 
 var _ parser.Client = (*ParserImpl)(nil)
 
 func (p *ParserImpl) ScheduleDatabaseCleanup(ctx context.Context) error {
-	loc, err := time.LoadLocation("Asia/Ho_Chi_Minh")
-	if err != nil {
-		loc = time.Local
-		p.Logger.Warn("Failed to load Asia/Ho_Chi_Minh timezone, using local timezone", "error", err)
-	}
+	return p.Scheduler.Schedule(ctx, scheduler.Job{
+		Name: "cleanup",
+		Definition: gocron.DailyJob(
+			1,
+			gocron.NewAtTimes(gocron.NewAtTime(3, 0, 0)),
+		),
+		Task: p.cleanupDatabase,
+	})
+}
+
+func (p *ParserImpl) cleanupDatabase(ctx context.Context) error {
+	p.Logger.Info("Starting scheduled database cleanup job")
+
+	cleanupCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
 
-	scheduler, err := gocron.NewScheduler(gocron.WithLocation(loc))
+	const cleanupDuration = 5 * 24 * time.Hour
+
+	rowsDeleted, err := p.StoryRepo.CleanupOldRecords(cleanupCtx, cleanupDuration)
 	if err != nil {
-		return fmt.Errorf("failed to create cleanup scheduler: %w", err)
+		return fmt.Errorf("failed to clean up old records: %w", err)
 	}
 
-	_, err = scheduler.NewJob(
-		gocron.DailyJob(
-			1,
-			gocron.NewAtTimes(gocron.NewAtTime(3, 0, 0)),
-		),
-		gocron.NewTask(func() {
-			if ctx.Err() != nil {
-				p.Logger.Info("Context cancelled, stopping database cleanup job")
-				return
-			}
+	p.Logger.Info("Database cleanup completed successfully", "rows_deleted", rowsDeleted)
 
-			p.Logger.Info("Starting scheduled database cleanup job")
+	deliveriesDeleted, err := p.DeliveryRepo.CleanupOldRecords(cleanupCtx, cleanupDuration)
+	if err != nil {
+		return fmt.Errorf("failed to clean up old deliveries: %w", err)
+	}
 
-			cleanupCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
-			defer cancel()
+	p.Logger.Info("Delivery cleanup completed successfully", "rows_deleted", deliveriesDeleted)
 
-			const cleanupDuration = 5 * 24 * time.Hour
+	// Dead messages and jobs are kept longer so they can still be inspected and requeued
+	const deadOutboxRetention = 30 * 24 * time.Hour
 
-			rowsDeleted, err := p.StoryRepo.CleanupOldRecords(cleanupCtx, cleanupDuration)
-			if err != nil {
-				p.Logger.Error("Failed to clean up old records", "error", err)
-				return
-			}
+	sentDeleted, err := p.OutboxRepo.CleanupOldRecords(cleanupCtx, domain.OutboxStatusSent, cleanupDuration)
+	if err != nil {
+		return fmt.Errorf("failed to clean up sent outbox messages: %w", err)
+	}
+	deadDeleted, err := p.OutboxRepo.CleanupOldRecords(cleanupCtx, domain.OutboxStatusDead, deadOutboxRetention)
+	if err != nil {
+		return fmt.Errorf("failed to clean up dead outbox messages: %w", err)
+	}
 
-			p.Logger.Info("Database cleanup completed successfully", "rows_deleted", rowsDeleted)
-		}),
-	)
+	p.Logger.Info("Outbox cleanup completed successfully", "sent_deleted", sentDeleted, "dead_deleted", deadDeleted)
 
+	deadJobsDeleted, err := p.ScrapeJobRepo.CleanupDeadJobs(cleanupCtx, deadOutboxRetention)
 	if err != nil {
-		return fmt.Errorf("failed to schedule database cleanup: %w", err)
+		return fmt.Errorf("failed to clean up dead scrape jobs: %w", err)
 	}
 
-	scheduler.Start()
+	p.Logger.Info("Dead scrape job cleanup completed successfully", "rows_deleted", deadJobsDeleted)
 
-	go func() {
-		<-ctx.Done()
-		p.Logger.Info("Stopping database cleanup scheduler")
-		if err := scheduler.Shutdown(); err != nil {
-			p.Logger.Error("Failed to shut down cleanup scheduler", "error", err)
-		}
-	}()
+	const snapshotRetention = 90 * 24 * time.Hour
+
+	snapshotsDeleted, err := p.ProfileSnapshotRepo.DeleteOlderThan(cleanupCtx, snapshotRetention)
+	if err != nil {
+		return fmt.Errorf("failed to clean up old profile snapshots: %w", err)
+	}
 
+	p.Logger.Info("Profile snapshot cleanup completed successfully", "rows_deleted", snapshotsDeleted)
 	return nil
 }
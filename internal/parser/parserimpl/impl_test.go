@@ -0,0 +1,74 @@
+package paserimpl
+
+import (
+	"context"
+	"testing"
+
+	mock_instagram "github.com/orgball2608/insta-parser-telegram-bot/internal/instagram/mocks"
+	mock_accountpoll "github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/accountpoll/mocks"
+	mock_delivery "github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/delivery/mocks"
+	mock_outbox "github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/outbox/mocks"
+	mock_post "github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/post/mocks"
+	mock_profilesnapshot "github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/profilesnapshot/mocks"
+	mock_scrapejob "github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/scrapejob/mocks"
+	mock_story "github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/story/mocks"
+	mock_subscription "github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/subscription/mocks"
+	mock_telegram "github.com/orgball2608/insta-parser-telegram-bot/internal/telegram/mocks"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/config"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/logger"
+	"go.uber.org/mock/gomock"
+)
+
+// testMocks holds the mocked dependencies of a parser built by newTestParser.
+type testMocks struct {
+	instagram     *mock_instagram.MockClient
+	telegram      *mock_telegram.MockClient
+	stories       *mock_story.MockRepository
+	posts         *mock_post.MockRepository
+	deliveries    *mock_delivery.MockRepository
+	outbox        *mock_outbox.MockRepository
+	scrapeJobs    *mock_scrapejob.MockRepository
+	accountPolls  *mock_accountpoll.MockRepository
+	subscriptions *mock_subscription.MockRepository
+	profiles      *mock_profilesnapshot.MockRepository
+}
+
+// inlineTransactor runs the function without a database transaction.
+type inlineTransactor struct{}
+
+func (inlineTransactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func newTestParser(t *testing.T) (*ParserImpl, testMocks) {
+	ctrl := gomock.NewController(t)
+	m := testMocks{
+		instagram:     mock_instagram.NewMockClient(ctrl),
+		telegram:      mock_telegram.NewMockClient(ctrl),
+		stories:       mock_story.NewMockRepository(ctrl),
+		posts:         mock_post.NewMockRepository(ctrl),
+		deliveries:    mock_delivery.NewMockRepository(ctrl),
+		outbox:        mock_outbox.NewMockRepository(ctrl),
+		scrapeJobs:    mock_scrapejob.NewMockRepository(ctrl),
+		accountPolls:  mock_accountpoll.NewMockRepository(ctrl),
+		subscriptions: mock_subscription.NewMockRepository(ctrl),
+		profiles:      mock_profilesnapshot.NewMockRepository(ctrl),
+	}
+
+	p := New(Opts{
+		Instagram:           m.instagram,
+		Telegram:            m.telegram,
+		StoryRepo:           m.stories,
+		PostRepo:            m.posts,
+		DeliveryRepo:        m.deliveries,
+		OutboxRepo:          m.outbox,
+		ScrapeJobRepo:       m.scrapeJobs,
+		AccountPollRepo:     m.accountPolls,
+		SubscriptionRepo:    m.subscriptions,
+		ProfileSnapshotRepo: m.profiles,
+		Transactor:          inlineTransactor{},
+		Logger:              logger.New(logger.Opts{Env: "test"}),
+		Config:              &config.Config{},
+	})
+	return p, m
+}
@@ -0,0 +1,170 @@
+package paserimpl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/scheduler"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/formatter"
+)
+
+const (
+	// outboxBatchSize is the number of due messages sent per dispatcher run
+	outboxBatchSize = 50
+	// outboxBaseBackoff is the delay after the first failed attempt; it doubles with every
+	// further attempt up to outboxMaxBackoff
+	outboxBaseBackoff = time.Minute
+	outboxMaxBackoff  = time.Hour
+)
+
+// enqueueNotifications queues content for each chat in the outbox. Called inside the
+// transaction that records the content as seen.
+func (p *ParserImpl) enqueueNotifications(ctx context.Context, contentType, contentID string, content any, chatIDs []int64) error {
+	if len(chatIDs) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(content)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s %s: %w", contentType, contentID, err)
+	}
+
+	for _, chatID := range chatIDs {
+		msg := domain.OutboxMessage{
+			ContentType: contentType,
+			ContentID:   contentID,
+			ChatID:      chatID,
+			Payload:     payload,
+		}
+		if err := p.OutboxRepo.Enqueue(ctx, msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ScheduleOutboxDispatch sets up a job that sends the queued notifications, retrying failed
+// ones with exponential backoff until they are dead-lettered.
+func (p *ParserImpl) ScheduleOutboxDispatch(ctx context.Context) error {
+	interval := p.Config.Parser.OutboxDispatchInterval
+	p.Logger.Info("Setting up outbox dispatcher", "interval", interval)
+
+	return p.Scheduler.Schedule(ctx, scheduler.Job{
+		Name:       "outbox",
+		Definition: gocron.DurationJob(interval),
+		Task:       p.dispatchOutbox,
+	})
+}
+
+func (p *ParserImpl) dispatchOutbox(ctx context.Context) error {
+	messages, err := p.OutboxRepo.GetDue(ctx, outboxBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to get due outbox messages: %w", err)
+	}
+
+	for i, msg := range messages {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if i > 0 {
+			time.Sleep(time.Duration(500+rand.Intn(1000)) * time.Millisecond)
+		}
+		p.dispatchOutboxMessage(ctx, msg)
+	}
+
+	return nil
+}
+
+// dispatchOutboxMessage sends one message and records the outcome both in the outbox and in
+// the chat's delivery state
+func (p *ParserImpl) dispatchOutboxMessage(ctx context.Context, msg *domain.OutboxMessage) {
+	messageID, sendErr := p.sendOutboxMessage(msg)
+
+	delivery := domain.Delivery{
+		ContentType: msg.ContentType,
+		ContentID:   msg.ContentID,
+		ChatID:      msg.ChatID,
+		Status:      domain.DeliveryStatusSent,
+		MessageID:   messageID,
+	}
+
+	if sendErr == nil {
+		if err := p.OutboxRepo.MarkSent(ctx, msg.ID); err != nil {
+			p.Logger.Error("Failed to mark outbox message as sent", "id", msg.ID, "error", err)
+		}
+	} else {
+		attempts := msg.Attempts + 1
+		dead := attempts >= p.Config.Parser.OutboxMaxAttempts
+		nextAttemptAt := time.Now().Add(outboxBackoff(attempts))
+
+		if dead {
+			p.Logger.Error("Giving up on outbox message", "id", msg.ID, "type", msg.ContentType, "content_id", msg.ContentID, "chat_id", msg.ChatID, "attempts", attempts, "error", sendErr)
+		} else {
+			p.Logger.Warn("Failed to send outbox message, will retry", "id", msg.ID, "chat_id", msg.ChatID, "attempts", attempts, "next_attempt_at", nextAttemptAt, "error", sendErr)
+		}
+
+		if err := p.OutboxRepo.MarkFailed(ctx, msg.ID, sendErr.Error(), nextAttemptAt, dead); err != nil {
+			p.Logger.Error("Failed to mark outbox message as failed", "id", msg.ID, "error", err)
+		}
+
+		delivery.Status = domain.DeliveryStatusFailed
+		delivery.LastError = sendErr.Error()
+	}
+
+	if err := p.DeliveryRepo.RecordAttempt(ctx, delivery); err != nil {
+		p.Logger.Error("Failed to record delivery", "content_id", msg.ContentID, "chat_id", msg.ChatID, "error", err)
+	}
+}
+
+// sendOutboxMessage decodes the payload according to the content type and sends it
+func (p *ParserImpl) sendOutboxMessage(msg *domain.OutboxMessage) (int, error) {
+	switch msg.ContentType {
+	case domain.SubscriptionTypeStory:
+		var story domain.StoryItem
+		if err := json.Unmarshal(msg.Payload, &story); err != nil {
+			return 0, fmt.Errorf("failed to decode story payload: %w", err)
+		}
+		return p.Telegram.SendMedia(msg.ChatID, story.GetMedia(), formatter.StoryCaption(story.Username, story.TakenAt))
+	case domain.SubscriptionTypePost:
+		var post domain.PostItem
+		if err := json.Unmarshal(msg.Payload, &post); err != nil {
+			return 0, fmt.Errorf("failed to decode post payload: %w", err)
+		}
+		return p.sendPostToSubscriber(msg.ChatID, &post)
+	case domain.SubscriptionTypeReel:
+		var reel domain.PostItem
+		if err := json.Unmarshal(msg.Payload, &reel); err != nil {
+			return 0, fmt.Errorf("failed to decode reel payload: %w", err)
+		}
+		return p.sendReelToSubscriber(msg.ChatID, &reel)
+	case domain.SubscriptionTypeHighlight:
+		var update highlightUpdate
+		if err := json.Unmarshal(msg.Payload, &update); err != nil {
+			return 0, fmt.Errorf("failed to decode highlight payload: %w", err)
+		}
+		return 0, p.sendHighlightUpdate(msg.ChatID, update)
+	case domain.SubscriptionTypeProfile:
+		var update profileUpdate
+		if err := json.Unmarshal(msg.Payload, &update); err != nil {
+			return 0, fmt.Errorf("failed to decode profile payload: %w", err)
+		}
+		return p.sendProfileUpdate(msg.ChatID, update)
+	default:
+		return 0, fmt.Errorf("unknown outbox content type %q", msg.ContentType)
+	}
+}
+
+// outboxBackoff returns the delay before the next attempt after the given number of attempts
+func outboxBackoff(attempts int) time.Duration {
+	backoff := outboxBaseBackoff
+	for i := 1; i < attempts && backoff < outboxMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, outboxMaxBackoff)
+}
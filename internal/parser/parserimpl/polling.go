@@ -0,0 +1,152 @@
+package paserimpl
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+)
+
+const (
+	// pollTickInterval is how often the poll jobs look for accounts that are due
+	pollTickInterval = time.Minute
+	// pollHistoryWindow is how far back activity is counted. Stories are cleaned up after
+	// five days, so the window stays inside what is still stored.
+	pollHistoryWindow = 4 * 24 * time.Hour
+	// pollsPerItem is how many polls fit in the average gap between two items
+	pollsPerItem = 4
+	// pollJitter spreads polls of accounts with the same interval apart
+	pollJitter = 0.1
+)
+
+// adaptiveInterval picks the poll interval of an account with the given number of new items
+// within pollHistoryWindow. Quiet accounts are polled at max, busy ones down to min.
+func adaptiveInterval(items int, min, max time.Duration) time.Duration {
+	if max < min {
+		max = min
+	}
+	if items <= 0 {
+		return max
+	}
+
+	interval := pollHistoryWindow / time.Duration(items*pollsPerItem)
+	if interval < min {
+		return min
+	}
+	if interval > max {
+		return max
+	}
+	return interval
+}
+
+func jitter(d time.Duration) time.Duration {
+	offset := (rand.Float64()*2 - 1) * pollJitter
+	return d + time.Duration(float64(d)*offset)
+}
+
+// pollDueAccounts queues a check of every subscribed account whose next poll of jobType is
+// due, and schedules its following poll from its recent activity. Accounts that are polled
+// for both stories and posts get a single snapshot check covering both.
+func (p *ParserImpl) pollDueAccounts(ctx context.Context, jobType string) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	usernames, err := p.SubscriptionRepo.GetAllUniqueUsernamesByType(ctx, jobType)
+	if err != nil {
+		return fmt.Errorf("failed to get unique usernames from subscriptions: %w", err)
+	}
+
+	otherType := domain.SubscriptionTypePost
+	if jobType == domain.SubscriptionTypePost {
+		otherType = domain.SubscriptionTypeStory
+	}
+	otherUsernames, err := p.SubscriptionRepo.GetAllUniqueUsernamesByType(ctx, otherType)
+	if err != nil {
+		return fmt.Errorf("failed to get unique usernames from subscriptions: %w", err)
+	}
+	polledForBoth := make(map[string]bool, len(otherUsernames))
+	for _, username := range otherUsernames {
+		polledForBoth[username] = true
+	}
+
+	polls, err := p.AccountPollRepo.GetByJobType(ctx, jobType)
+	if err != nil {
+		return err
+	}
+
+	subscribed := make(map[string]bool, len(usernames))
+	for _, username := range usernames {
+		subscribed[username] = true
+	}
+
+	existing := make(map[string]*domain.AccountPoll, len(polls))
+	for _, poll := range polls {
+		if !subscribed[poll.Username] {
+			if err := p.AccountPollRepo.Delete(ctx, jobType, poll.Username); err != nil {
+				p.Logger.Error("Failed to delete account poll", "type", jobType, "username", poll.Username, "error", err)
+			}
+			continue
+		}
+		existing[poll.Username] = poll
+	}
+
+	now := time.Now()
+	var due, dueForBoth []string
+	for _, username := range usernames {
+		if poll, ok := existing[username]; ok && poll.NextPollAt.After(now) {
+			continue
+		}
+
+		if err := p.schedulePoll(ctx, jobType, username, now); err != nil {
+			p.Logger.Error("Failed to schedule account poll", "type", jobType, "username", username, "error", err)
+			continue
+		}
+
+		if !polledForBoth[username] {
+			due = append(due, username)
+			continue
+		}
+
+		// The snapshot covers the other type too, so its next poll starts over from now
+		if err := p.schedulePoll(ctx, otherType, username, now); err != nil {
+			p.Logger.Error("Failed to schedule account poll", "type", otherType, "username", username, "error", err)
+		}
+		dueForBoth = append(dueForBoth, username)
+	}
+
+	if len(due) > 0 {
+		p.enqueueScrapeJobs(ctx, jobType, shuffleUsernames(due))
+	}
+	if len(dueForBoth) > 0 {
+		p.enqueueScrapeJobs(ctx, domain.ScrapeJobTypeSnapshot, shuffleUsernames(dueForBoth))
+	}
+	return nil
+}
+
+// schedulePoll records a poll of username for jobType at now and picks the next one from the
+// account's recent activity.
+func (p *ParserImpl) schedulePoll(ctx context.Context, jobType, username string, now time.Time) error {
+	items, err := p.countRecentItems(ctx, jobType, username, now.Add(-pollHistoryWindow))
+	if err != nil {
+		return fmt.Errorf("failed to count recent items: %w", err)
+	}
+
+	interval := adaptiveInterval(items, p.Config.Parser.PollMinInterval, p.Config.Parser.PollMaxInterval)
+	return p.AccountPollRepo.Save(ctx, domain.AccountPoll{
+		Username:     username,
+		JobType:      jobType,
+		Interval:     interval,
+		ItemsSeen:    items,
+		LastPolledAt: &now,
+		NextPollAt:   now.Add(jitter(interval)),
+	})
+}
+
+func (p *ParserImpl) countRecentItems(ctx context.Context, jobType, username string, since time.Time) (int, error) {
+	if jobType == domain.SubscriptionTypePost {
+		return p.PostRepo.CountByUsernameSince(ctx, username, since)
+	}
+	return p.StoryRepo.CountByUsernameSince(ctx, username, since)
+}
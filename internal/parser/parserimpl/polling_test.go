@@ -0,0 +1,29 @@
+package paserimpl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPollDueAccountsOnlyScrapesStorySubscriptions(t *testing.T) {
+	p, m := newTestParser(t)
+
+	m.subscriptions.EXPECT().GetAllUniqueUsernamesByType(gomock.Any(), domain.SubscriptionTypeStory).Return([]string{"storyfan", "both"}, nil)
+	m.subscriptions.EXPECT().GetAllUniqueUsernamesByType(gomock.Any(), domain.SubscriptionTypePost).Return([]string{"both", "postonly"}, nil)
+	m.accountPolls.EXPECT().GetByJobType(gomock.Any(), domain.SubscriptionTypeStory).Return(nil, nil)
+
+	m.stories.EXPECT().CountByUsernameSince(gomock.Any(), gomock.Any(), gomock.Any()).Return(0, nil).AnyTimes()
+	m.posts.EXPECT().CountByUsernameSince(gomock.Any(), "both", gomock.Any()).Return(0, nil)
+	m.accountPolls.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	// The post-only account is never scraped for stories
+	m.scrapeJobs.EXPECT().Enqueue(gomock.Any(), domain.SubscriptionTypeStory, "storyfan", gomock.Any()).Return(true, nil)
+	m.scrapeJobs.EXPECT().Enqueue(gomock.Any(), domain.ScrapeJobTypeSnapshot, "both", gomock.Any()).Return(true, nil)
+
+	if err := p.pollDueAccounts(context.Background(), domain.SubscriptionTypeStory); err != nil {
+		t.Fatalf("pollDueAccounts() error = %v", err)
+	}
+}
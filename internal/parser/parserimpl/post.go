@@ -2,90 +2,56 @@ package paserimpl
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/go-co-op/gocron/v2"
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/post"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/scheduler"
 	"github.com/orgball2608/insta-parser-telegram-bot/pkg/formatter"
 )
 
-// SchedulePostChecking sets up a scheduler to check for new posts
+// SchedulePostChecking polls each account's posts at the interval picked from its activity
 func (p *ParserImpl) SchedulePostChecking(ctx context.Context) error {
-	p.Logger.Info("Setting up post checking scheduler")
-
-	// Create a scheduler if not already created
-	if p.Scheduler == nil {
-		loc, err := time.LoadLocation("Asia/Ho_Chi_Minh")
-		if err != nil {
-			loc = time.Local
-			p.Logger.Warn("Failed to load Asia/Ho_Chi_Minh timezone, using local timezone", "error", err)
-		}
-
-		scheduler, err := gocron.NewScheduler(gocron.WithLocation(loc))
-		if err != nil {
-			return fmt.Errorf("failed to create post check scheduler: %w", err)
-		}
-		p.Scheduler = scheduler
-	}
-
-	// Parse the interval from config
-	interval := p.Config.Parser.PostCheckInterval
-	p.Logger.Info("Setting up post check interval", "interval", interval)
-
-	// Create a job with the specified interval
-	_, err := p.Scheduler.NewJob(
-		gocron.CronJob(
-			interval,
-			false, // Don't use seconds precision
-		),
-		gocron.NewTask(func() {
-			p.Logger.Info("Running scheduled post check")
-
-			// Create a context with timeout for the post checking operation
-			checkCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
-			defer cancel()
-
-			// Get all usernames with post or all subscription types
-			usernames, err := p.SubscriptionRepo.GetAllUniqueUsernamesByType(checkCtx, domain.SubscriptionTypePost)
-			if err != nil {
-				p.Logger.Error("Failed to get usernames for post checking", "error", err)
-				return
-			}
-
-			p.Logger.Info("Checking posts for users", "count", len(usernames))
-
-			// Process each username
-			for _, username := range usernames {
-				p.checkNewPostsForUser(checkCtx, username)
-			}
-		}),
-	)
-
-	if err != nil {
-		return fmt.Errorf("failed to schedule post checking: %w", err)
-	}
-
-	// Start the scheduler if not already started
-	p.Scheduler.Start()
-
-	return nil
+	return p.Scheduler.Schedule(ctx, scheduler.Job{
+		Name:       "posts",
+		Definition: gocron.DurationJob(pollTickInterval),
+		Task: func(ctx context.Context) error {
+			return p.pollDueAccounts(ctx, domain.SubscriptionTypePost)
+		},
+	})
 }
 
-// checkNewPostsForUser checks for new posts for a specific user
-func (p *ParserImpl) checkNewPostsForUser(ctx context.Context, username string) {
+// checkNewPostsForUser checks for new posts for a specific user. Failures of single posts are
+// logged and skipped; the returned error means the account could not be checked at all.
+func (p *ParserImpl) checkNewPostsForUser(ctx context.Context, username string) error {
 	p.Logger.Info("Checking new posts", "username", username)
 
 	// Get the latest posts from Instagram
 	posts, err := p.Instagram.GetUserPosts(ctx, username)
 	if err != nil {
-		p.Logger.Error("Failed to get posts", "username", username, "error", err)
-		return
+		return fmt.Errorf("failed to get posts for %s: %w", username, err)
 	}
 
 	p.Logger.Info("Retrieved posts", "username", username, "count", len(posts))
+	return p.queueNewPosts(ctx, username, posts)
+}
+
+// queueNewPosts records the listed posts of username that were not seen before and queues
+// them for the subscribers.
+func (p *ParserImpl) queueNewPosts(ctx context.Context, username string, posts []domain.PostItem) error {
+	// The first time an account is checked, only record its current posts so subscribers
+	// are not flooded with the whole posts tab.
+	known, err := p.PostRepo.GetLatestByUsernameAndSource(ctx, username, domain.PostSourcePost, 1)
+	if err != nil {
+		return fmt.Errorf("failed to get known posts for %s: %w", username, err)
+	}
+	if len(known) == 0 {
+		p.baselinePosts(ctx, username, domain.PostSourcePost, posts)
+		return nil
+	}
 
 	// Process each post
 	for _, postItem := range posts {
@@ -108,38 +74,97 @@ func (p *ParserImpl) checkNewPostsForUser(ctx context.Context, username string)
 			continue
 		}
 
-		// Save the post to the database
+		// The listing ID is the canonical one; the detail page may not expose it.
+		fullPost.ID = postItem.ID
+		if fullPost.Username == "" {
+			fullPost.Username = username
+		}
+		if !strings.Contains(fullPost.PostURL, "/p/") && !strings.Contains(fullPost.PostURL, "/reel/") {
+			fullPost.PostURL = postItem.PostURL
+		}
+
+		fullPost.IsReel = fullPost.IsReel || postItem.IsReel
+
+		// Get subscribers for this username who want post updates; a reel is recorded once, by
+		// whichever checker sees it first, so that checker also delivers it to reel subscribers
+		subscriptionTypes := []string{domain.SubscriptionTypePost}
+		if fullPost.IsReel {
+			subscriptionTypes = append(subscriptionTypes, domain.SubscriptionTypeReel)
+		}
+		subscribers, err := p.subscribersForTypes(ctx, username, subscriptionTypes...)
+		if err != nil {
+			p.Logger.Error("Failed to get subscribers", "username", username, "error", err)
+			continue
+		}
+
 		postParser := domain.PostParser{
 			PostID:   fullPost.ID,
 			Username: fullPost.Username,
 			PostURL:  fullPost.PostURL,
+			Source:   domain.PostSourcePost,
 		}
 
-		if err := p.PostRepo.Create(ctx, postParser); err != nil {
-			if err != post.ErrAlreadyExists {
+		// Save the post and queue its notifications in one transaction; the outbox
+		// dispatcher sends them
+		err = p.Transactor.WithinTx(ctx, func(ctx context.Context) error {
+			if err := p.PostRepo.Create(ctx, postParser); err != nil {
+				return err
+			}
+			return p.enqueueNotifications(ctx, domain.SubscriptionTypePost, fullPost.ID, fullPost, subscribers)
+		})
+		if err != nil {
+			if !errors.Is(err, post.ErrAlreadyExists) {
 				p.Logger.Error("Failed to save post", "postID", fullPost.ID, "error", err)
 			}
 			continue
 		}
 
-		// Get subscribers for this username who want post updates
-		subscribers, err := p.SubscriptionRepo.GetSubscribersForUserByType(ctx, username, domain.SubscriptionTypePost)
-		if err != nil {
-			p.Logger.Error("Failed to get subscribers", "username", username, "error", err)
-			continue
-		}
+		p.Logger.Info("Queued post for subscribers", "username", username, "postID", fullPost.ID, "subscriberCount", len(subscribers))
+	}
 
-		p.Logger.Info("Sending post to subscribers", "username", username, "postID", fullPost.ID, "subscriberCount", len(subscribers))
+	return nil
+}
 
-		// Send the post to each subscriber
-		for _, chatID := range subscribers {
-			p.sendPostToSubscriber(ctx, chatID, fullPost)
+// baselinePosts records the posts or reels of a newly tracked account as already seen.
+func (p *ParserImpl) baselinePosts(ctx context.Context, username, source string, posts []domain.PostItem) {
+	for _, postItem := range posts {
+		postParser := domain.PostParser{
+			PostID:   postItem.ID,
+			Username: username,
+			PostURL:  postItem.PostURL,
+			Source:   source,
+			Baseline: true,
+		}
+		if err := p.PostRepo.Create(ctx, postParser); err != nil && !errors.Is(err, post.ErrAlreadyExists) {
+			p.Logger.Error("Failed to record existing post", "postID", postItem.ID, "error", err)
 		}
 	}
+	p.Logger.Info("Recorded existing posts for newly tracked account", "username", username, "source", source, "count", len(posts))
 }
 
-// sendPostToSubscriber sends a post to a subscriber
-func (p *ParserImpl) sendPostToSubscriber(ctx context.Context, chatID int64, post *domain.PostItem) {
+// subscribersForTypes returns the chats subscribed to username with any of the given types,
+// each chat once.
+func (p *ParserImpl) subscribersForTypes(ctx context.Context, username string, subscriptionTypes ...string) ([]int64, error) {
+	var subscribers []int64
+	seen := make(map[int64]bool)
+	for _, subscriptionType := range subscriptionTypes {
+		chatIDs, err := p.SubscriptionRepo.GetSubscribersForUserByType(ctx, username, subscriptionType)
+		if err != nil {
+			return nil, err
+		}
+		for _, chatID := range chatIDs {
+			if !seen[chatID] {
+				seen[chatID] = true
+				subscribers = append(subscribers, chatID)
+			}
+		}
+	}
+	return subscribers, nil
+}
+
+// sendPostToSubscriber sends a post to a subscriber and returns the ID of the text message
+// when it had to fall back to one
+func (p *ParserImpl) sendPostToSubscriber(chatID int64, post *domain.PostItem) (int, error) {
 	// Escape username and caption for Markdown
 	escapedUsername := formatter.EscapeMarkdownV2(post.Username)
 	escapedCaption := formatter.EscapeMarkdownV2(post.Caption)
@@ -160,21 +185,14 @@ func (p *ParserImpl) sendPostToSubscriber(ctx context.Context, chatID int64, pos
 		message += fmt.Sprintf("🔗 [View on Instagram](%s)", post.PostURL)
 	}
 
-	// Send message with media if available
-	if len(post.MediaURLs) > 0 {
-		// For simplicity, just send the first media item
-		// In a real implementation, you might want to send all media items as an album
-		mediaURL := post.MediaURLs[0]
-
-		if post.IsVideo {
-			// Use SendMessage with the URL if SendVideo is not available
-			p.Telegram.SendMessage(chatID, fmt.Sprintf("%s\n\n🎬 [Watch Video](%s)", message, mediaURL))
-		} else {
-			// Use SendMessage with the URL if SendPhoto is not available
-			p.Telegram.SendMessage(chatID, fmt.Sprintf("%s\n\n🖼️ [View Image](%s)", message, mediaURL))
+	// Send the whole carousel as an album with the message as caption
+	if len(post.Media) > 0 {
+		err := p.Telegram.SendMediaAlbum(chatID, post.Media, message)
+		if err == nil {
+			return 0, nil
 		}
-	} else {
-		// No media, just send the message
-		p.Telegram.SendMessage(chatID, message)
+		p.Logger.Error("Failed to send post media, sending text only", "chatID", chatID, "postID", post.ID, "error", err)
 	}
+
+	return p.Telegram.SendMessage(chatID, message)
 }
@@ -0,0 +1,257 @@
+package paserimpl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/profilesnapshot"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/scheduler"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/formatter"
+)
+
+// followerMilestones are the follower counts whose upward crossing is announced.
+var followerMilestones = []int{
+	1_000, 5_000, 10_000, 50_000, 100_000, 500_000,
+	1_000_000, 5_000_000, 10_000_000, 50_000_000, 100_000_000,
+}
+
+// profileDiff describes what changed between two snapshots of a profile.
+type profileDiff struct {
+	Changes       []string
+	AvatarChanged bool
+}
+
+func (d profileDiff) empty() bool {
+	return len(d.Changes) == 0
+}
+
+// ScheduleProfileChecking sets up a job that compares the profiles of accounts with profile
+// subscriptions against their last snapshot and notifies subscribers of changes.
+func (p *ParserImpl) ScheduleProfileChecking(ctx context.Context) error {
+	interval := p.Config.Parser.ProfileCheckInterval
+	p.Logger.Info("Setting up profile checking scheduler", "interval", interval)
+
+	return p.Scheduler.Schedule(ctx, scheduler.Job{
+		Name:       "profiles",
+		Definition: gocron.CronJob(interval, false),
+		Task: func(ctx context.Context) error {
+			p.Logger.Info("Running scheduled profile check")
+
+			checkCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+			defer cancel()
+
+			usernames, err := p.SubscriptionRepo.GetAllUniqueUsernamesByType(checkCtx, domain.SubscriptionTypeProfile)
+			if err != nil {
+				return fmt.Errorf("failed to get usernames for profile checking: %w", err)
+			}
+
+			for _, username := range usernames {
+				if checkCtx.Err() != nil {
+					return checkCtx.Err()
+				}
+				p.checkProfileChanges(checkCtx, username)
+			}
+			return nil
+		},
+	})
+}
+
+// checkProfileChanges fetches the current profile of username and, if it differs from the
+// stored snapshot, stores a new snapshot and queues the differences for subscribers.
+func (p *ParserImpl) checkProfileChanges(ctx context.Context, username string) {
+	current, err := p.Instagram.GetUserProfile(ctx, username)
+	if err != nil {
+		p.Logger.Error("Failed to get profile", "username", username, "error", err)
+		return
+	}
+
+	p.compareProfile(ctx, username, current)
+}
+
+// profileUpdate is the outbox payload of one profile change. The avatars are carried inline,
+// like in the snapshots, since their CDN links expire before a retry would run.
+type profileUpdate struct {
+	Username  string   `json:"username"`
+	Changes   []string `json:"changes"`
+	OldAvatar []byte   `json:"old_avatar,omitempty"`
+	NewAvatar []byte   `json:"new_avatar,omitempty"`
+}
+
+// compareProfile compares a freshly read profile with the stored snapshot of username.
+func (p *ParserImpl) compareProfile(ctx context.Context, username string, current *domain.UserProfile) {
+	previous, err := p.ProfileSnapshotRepo.GetLatest(ctx, username)
+	if errors.Is(err, profilesnapshot.ErrNotFound) {
+		// The first snapshot of a newly tracked account is only recorded.
+		snapshot := domain.ProfileSnapshot{UserProfile: *current, Avatar: p.downloadAvatar(*current)}
+		if _, err := p.ProfileSnapshotRepo.Create(ctx, snapshot); err != nil {
+			p.Logger.Error("Failed to save profile snapshot", "username", username, "error", err)
+		}
+		return
+	}
+	if err != nil {
+		p.Logger.Error("Failed to get profile snapshot", "username", username, "error", err)
+		return
+	}
+
+	// Some scrapers cannot read the header of a private account, so only its privacy flag is compared.
+	if current.IsPrivate && current.AvatarURL == "" {
+		kept := previous.UserProfile
+		kept.IsPrivate = true
+		current = &kept
+	}
+
+	diff := diffProfiles(previous.UserProfile, *current)
+	if diff.empty() {
+		return
+	}
+
+	subscribers, err := p.SubscriptionRepo.GetSubscribersForUserByType(ctx, username, domain.SubscriptionTypeProfile)
+	if err != nil {
+		p.Logger.Error("Failed to get subscribers", "username", username, "error", err)
+		return
+	}
+
+	update := profileUpdate{Username: username, Changes: diff.Changes}
+	avatar := previous.Avatar
+	if diff.AvatarChanged {
+		avatar = p.downloadAvatar(*current)
+		update.OldAvatar = previous.Avatar
+		update.NewAvatar = avatar
+	}
+
+	// The snapshot and its notifications are stored together, so a crash or a Telegram outage
+	// can't record a change that is never sent
+	err = p.Transactor.WithinTx(ctx, func(ctx context.Context) error {
+		snapshotID, err := p.ProfileSnapshotRepo.Create(ctx, domain.ProfileSnapshot{UserProfile: *current, Avatar: avatar})
+		if err != nil {
+			return err
+		}
+		// Every change gets its own snapshot, so the snapshot ID identifies the notification
+		return p.enqueueNotifications(ctx, domain.SubscriptionTypeProfile, strconv.Itoa(snapshotID), update, subscribers)
+	})
+	if err != nil {
+		p.Logger.Error("Failed to save profile changes", "username", username, "error", err)
+		return
+	}
+
+	p.Logger.Info("Queued profile changes for subscribers", "username", username, "changes", len(diff.Changes), "subscriberCount", len(subscribers))
+}
+
+// downloadAvatar returns the avatar of profile, or nil when it has none or cannot be downloaded.
+func (p *ParserImpl) downloadAvatar(profile domain.UserProfile) []byte {
+	if profile.AvatarURL == "" {
+		return nil
+	}
+
+	data, err := p.Telegram.DownloadMedia(profile.AvatarURL)
+	if err != nil {
+		p.Logger.Warn("Could not download avatar for profile snapshot", "username", profile.Username, "error", err)
+		return nil
+	}
+	return data
+}
+
+// sendProfileUpdate sends the changes of a profile, followed by the old and new avatar when
+// the profile picture changed.
+func (p *ParserImpl) sendProfileUpdate(chatID int64, update profileUpdate) (int, error) {
+	message := fmt.Sprintf("👤 Profile of @%s changed:\n\n• %s", update.Username, strings.Join(update.Changes, "\n• "))
+	messageID, err := p.Telegram.SendMessage(chatID, message)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := p.sendAvatarChange(chatID, update.OldAvatar, update.NewAvatar); err != nil {
+		return messageID, fmt.Errorf("failed to send avatar change: %w", err)
+	}
+	return messageID, nil
+}
+
+// sendAvatarChange sends the old and new avatar side by side, or whichever one is available.
+func (p *ParserImpl) sendAvatarChange(chatID int64, oldAvatar, newAvatar []byte) error {
+	var group []interface{}
+	if len(oldAvatar) > 0 {
+		photo := tgbotapi.NewInputMediaPhoto(tgbotapi.FileBytes{Name: "old_avatar.jpg", Bytes: oldAvatar})
+		photo.Caption = "Before"
+		group = append(group, photo)
+	}
+	if len(newAvatar) > 0 {
+		photo := tgbotapi.NewInputMediaPhoto(tgbotapi.FileBytes{Name: "new_avatar.jpg", Bytes: newAvatar})
+		photo.Caption = "After"
+		group = append(group, photo)
+	}
+
+	switch len(group) {
+	case 0:
+		return nil
+	case 1:
+		// Telegram rejects albums with a single item
+		photo := group[0].(tgbotapi.InputMediaPhoto)
+		msg := tgbotapi.NewPhoto(chatID, photo.Media)
+		msg.Caption = photo.Caption
+		_, err := p.Telegram.Send(msg)
+		return err
+	default:
+		return p.Telegram.SendMediaGroup(chatID, group)
+	}
+}
+
+// diffProfiles lists the human-readable changes from old to after. Follower counts are only
+// reported when they cross a milestone upwards, so small fluctuations stay quiet.
+func diffProfiles(before, after domain.UserProfile) profileDiff {
+	var diff profileDiff
+
+	if before.FullName != after.FullName {
+		diff.Changes = append(diff.Changes, fmt.Sprintf("Name: %q → %q", before.FullName, after.FullName))
+	}
+	if before.Biography != after.Biography {
+		diff.Changes = append(diff.Changes, fmt.Sprintf("Bio changed:\n%s\n→\n%s", quoteOrEmpty(before.Biography), quoteOrEmpty(after.Biography)))
+	}
+	if before.AvatarKey() != after.AvatarKey() && after.AvatarURL != "" {
+		diff.Changes = append(diff.Changes, "New profile picture")
+		diff.AvatarChanged = true
+	}
+	if before.IsPrivate != after.IsPrivate {
+		if after.IsPrivate {
+			diff.Changes = append(diff.Changes, "The account is now private 🔒")
+		} else {
+			diff.Changes = append(diff.Changes, "The account is now public 🔓")
+		}
+	}
+	if before.IsVerified != after.IsVerified {
+		if after.IsVerified {
+			diff.Changes = append(diff.Changes, "The account is now verified ✔️")
+		} else {
+			diff.Changes = append(diff.Changes, "The account lost its verification")
+		}
+	}
+	if milestone := highestMilestone(after.FollowerCount); milestone > highestMilestone(before.FollowerCount) {
+		diff.Changes = append(diff.Changes, fmt.Sprintf("Reached %s followers 🎉 (now %s)",
+			formatter.FormatNumber(milestone), formatter.FormatNumber(after.FollowerCount)))
+	}
+
+	return diff
+}
+
+func highestMilestone(followers int) int {
+	reached := 0
+	for _, m := range followerMilestones {
+		if followers >= m {
+			reached = m
+		}
+	}
+	return reached
+}
+
+func quoteOrEmpty(s string) string {
+	if s == "" {
+		return "(empty)"
+	}
+	return s
+}
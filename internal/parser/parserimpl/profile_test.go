@@ -0,0 +1,64 @@
+package paserimpl
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCompareProfileQueuesChanges(t *testing.T) {
+	p, m := newTestParser(t)
+
+	previous := &domain.ProfileSnapshot{
+		UserProfile: domain.UserProfile{Username: "someone", FullName: "Old", AvatarURL: "https://cdn.example.com/old.jpg"},
+		Avatar:      []byte("old"),
+	}
+	current := &domain.UserProfile{Username: "someone", FullName: "New", AvatarURL: "https://cdn.example.com/new.jpg"}
+
+	m.profiles.EXPECT().GetLatest(gomock.Any(), "someone").Return(previous, nil)
+	m.subscriptions.EXPECT().GetSubscribersForUserByType(gomock.Any(), "someone", domain.SubscriptionTypeProfile).Return([]int64{1, 2}, nil)
+	m.telegram.EXPECT().DownloadMedia("https://cdn.example.com/new.jpg").Return([]byte("new"), nil)
+	m.profiles.EXPECT().Create(gomock.Any(), domain.ProfileSnapshot{UserProfile: *current, Avatar: []byte("new")}).Return(7, nil)
+	for _, chatID := range []int64{1, 2} {
+		m.outbox.EXPECT().Enqueue(gomock.Any(), gomock.Cond(func(msg domain.OutboxMessage) bool {
+			var update profileUpdate
+			if err := json.Unmarshal(msg.Payload, &update); err != nil {
+				return false
+			}
+			return msg.ContentType == domain.SubscriptionTypeProfile && msg.ContentID == "7" && msg.ChatID == chatID &&
+				len(update.Changes) == 2 && string(update.OldAvatar) == "old" && string(update.NewAvatar) == "new"
+		})).Return(nil)
+	}
+
+	p.compareProfile(context.Background(), "someone", current)
+}
+
+func TestSendOutboxProfileUpdate(t *testing.T) {
+	p, m := newTestParser(t)
+
+	payload, err := json.Marshal(profileUpdate{
+		Username:  "someone",
+		Changes:   []string{"New profile picture"},
+		OldAvatar: []byte("old"),
+		NewAvatar: []byte("new"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m.telegram.EXPECT().SendMessage(int64(1), "👤 Profile of @someone changed:\n\n• New profile picture").Return(42, nil)
+	m.telegram.EXPECT().SendMediaGroup(int64(1), gomock.Len(2)).Return(nil)
+
+	messageID, err := p.sendOutboxMessage(&domain.OutboxMessage{
+		ContentType: domain.SubscriptionTypeProfile,
+		ContentID:   "7",
+		ChatID:      1,
+		Payload:     payload,
+	})
+	if err != nil || messageID != 42 {
+		t.Fatalf("sendOutboxMessage() = %d, %v, want 42", messageID, err)
+	}
+}
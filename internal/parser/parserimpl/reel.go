@@ -0,0 +1,165 @@
+package paserimpl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/instagram"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/post"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/scheduler"
+)
+
+// maxMediaCaptionLength is Telegram's limit for photo and video captions.
+const maxMediaCaptionLength = 1024
+
+// ScheduleReelChecking sets up a job that lists the reels tab of accounts with reel
+// subscriptions and delivers new reels as videos.
+func (p *ParserImpl) ScheduleReelChecking(ctx context.Context) error {
+	interval := p.Config.Parser.ReelCheckInterval
+	p.Logger.Info("Setting up reel checking scheduler", "interval", interval)
+
+	return p.Scheduler.Schedule(ctx, scheduler.Job{
+		Name:       "reels",
+		Definition: gocron.CronJob(interval, false),
+		Task: func(ctx context.Context) error {
+			p.Logger.Info("Running scheduled reel check")
+
+			checkCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+			defer cancel()
+
+			usernames, err := p.SubscriptionRepo.GetAllUniqueUsernamesByType(checkCtx, domain.SubscriptionTypeReel)
+			if err != nil {
+				return fmt.Errorf("failed to get usernames for reel checking: %w", err)
+			}
+
+			for _, username := range usernames {
+				if checkCtx.Err() != nil {
+					return checkCtx.Err()
+				}
+				p.checkNewReelsForUser(checkCtx, username)
+			}
+			return nil
+		},
+	})
+}
+
+// checkNewReelsForUser delivers the reels of username that neither this job nor the post
+// checker has recorded yet.
+func (p *ParserImpl) checkNewReelsForUser(ctx context.Context, username string) {
+	reels, err := p.Instagram.GetUserReels(ctx, username)
+	if err != nil {
+		if errors.Is(err, instagram.ErrNotSupported) {
+			p.Logger.Warn("No provider can list reels", "username", username)
+			return
+		}
+		p.Logger.Error("Failed to get reels", "username", username, "error", err)
+		return
+	}
+
+	// Reels also show up in the posts tab, so an account the post checker already tracks is
+	// not new here even though none of its rows were recorded by this job.
+	known, err := p.PostRepo.GetLatestByUsername(ctx, username, 1)
+	if err != nil {
+		p.Logger.Error("Failed to get known reels", "username", username, "error", err)
+		return
+	}
+	if len(known) == 0 {
+		p.baselinePosts(ctx, username, domain.PostSourceReel, reels)
+		return
+	}
+
+	for _, reelItem := range reels {
+		// Reels also show up in the posts tab, so whichever checker records one first delivers it.
+		exists, err := p.PostRepo.Exists(ctx, reelItem.ID)
+		if err != nil {
+			p.Logger.Error("Failed to check if reel exists", "reelID", reelItem.ID, "error", err)
+			continue
+		}
+		if exists {
+			continue
+		}
+
+		reel, err := p.Instagram.GetUserReel(ctx, reelItem.PostURL)
+		if err != nil {
+			p.Logger.Error("Failed to get reel details", "reelURL", reelItem.PostURL, "error", err)
+			continue
+		}
+		if len(reel.Media) == 0 {
+			p.Logger.Warn("Reel has no media, skipping", "reelURL", reelItem.PostURL)
+			continue
+		}
+
+		reel.ID = reelItem.ID
+		reel.PostURL = reelItem.PostURL
+		if reel.Username == "" {
+			reel.Username = username
+		}
+
+		subscribers, err := p.subscribersForTypes(ctx, username, domain.SubscriptionTypeReel, domain.SubscriptionTypePost)
+		if err != nil {
+			p.Logger.Error("Failed to get subscribers", "username", username, "error", err)
+			continue
+		}
+
+		// Save the reel and queue its notifications in one transaction, as for posts
+		err = p.Transactor.WithinTx(ctx, func(ctx context.Context) error {
+			err := p.PostRepo.Create(ctx, domain.PostParser{
+				PostID:   reel.ID,
+				Username: username,
+				PostURL:  reel.PostURL,
+				Source:   domain.PostSourceReel,
+			})
+			if err != nil {
+				return err
+			}
+			return p.enqueueNotifications(ctx, domain.SubscriptionTypeReel, reel.ID, reel, subscribers)
+		})
+		if err != nil {
+			if !errors.Is(err, post.ErrAlreadyExists) {
+				p.Logger.Error("Failed to save reel", "reelID", reel.ID, "error", err)
+			}
+			continue
+		}
+
+		p.Logger.Info("Queued reel for subscribers", "username", username, "reelID", reel.ID, "subscriberCount", len(subscribers))
+	}
+}
+
+// sendReelToSubscriber sends a reel as a playable video, with its caption alongside or, when
+// it is too long for a video caption, as a follow-up message. The reel counts as delivered
+// only once the video went out, so a failed video is retried by the outbox.
+func (p *ParserImpl) sendReelToSubscriber(chatID int64, reel *domain.PostItem) (int, error) {
+	var caption strings.Builder
+	caption.WriteString(fmt.Sprintf("🎬 New reel from @%s\n\n", reel.Username))
+	if reel.Caption != "" {
+		caption.WriteString(reel.Caption + "\n\n")
+	}
+	caption.WriteString(reel.PostURL)
+	message := caption.String()
+
+	video := reel.Media[0]
+	video.Type = domain.MediaTypeVideo
+
+	videoCaption := message
+	if len([]rune(message)) > maxMediaCaptionLength {
+		videoCaption = ""
+	}
+
+	messageID, err := p.Telegram.SendMedia(chatID, video, videoCaption)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send reel video: %w", err)
+	}
+
+	if videoCaption == "" {
+		// The video is already out, so a retry would send it twice; the caption is only logged
+		if _, err := p.Telegram.SendMessage(chatID, message); err != nil {
+			p.Logger.Error("Failed to send reel caption", "chatID", chatID, "reelID", reel.ID, "error", err)
+		}
+	}
+	return messageID, nil
+}
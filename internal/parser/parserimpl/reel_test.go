@@ -0,0 +1,117 @@
+package paserimpl
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	"go.uber.org/mock/gomock"
+)
+
+var testReelItems = []domain.PostItem{
+	{ID: "known", PostURL: "https://www.instagram.com/reel/known/", IsReel: true},
+	{ID: "fresh", PostURL: "https://www.instagram.com/reel/fresh/", IsReel: true},
+}
+
+func TestCheckNewReelsForUserTrackedByPostChecker(t *testing.T) {
+	p, m := newTestParser(t)
+
+	m.instagram.EXPECT().GetUserReels(gomock.Any(), "someone").Return(testReelItems, nil)
+	// Only the post checker has recorded the account so far
+	m.posts.EXPECT().GetLatestByUsername(gomock.Any(), "someone", 1).Return([]*domain.PostParser{
+		{PostID: "known", Username: "someone", Source: domain.PostSourcePost},
+	}, nil)
+	m.posts.EXPECT().Exists(gomock.Any(), "known").Return(true, nil)
+	m.posts.EXPECT().Exists(gomock.Any(), "fresh").Return(false, nil)
+	m.instagram.EXPECT().GetUserReel(gomock.Any(), "https://www.instagram.com/reel/fresh/").Return(&domain.PostItem{
+		Media: []domain.MediaItem{{Type: domain.MediaTypeVideo, URL: "https://cdn.example.com/fresh.mp4"}},
+	}, nil)
+	m.subscriptions.EXPECT().GetSubscribersForUserByType(gomock.Any(), "someone", domain.SubscriptionTypeReel).Return([]int64{1}, nil)
+	m.subscriptions.EXPECT().GetSubscribersForUserByType(gomock.Any(), "someone", domain.SubscriptionTypePost).Return([]int64{1, 2}, nil)
+	m.posts.EXPECT().Create(gomock.Any(), domain.PostParser{
+		PostID:   "fresh",
+		Username: "someone",
+		PostURL:  "https://www.instagram.com/reel/fresh/",
+		Source:   domain.PostSourceReel,
+	}).Return(nil)
+	for _, chatID := range []int64{1, 2} {
+		m.outbox.EXPECT().Enqueue(gomock.Any(), gomock.Cond(func(msg domain.OutboxMessage) bool {
+			return msg.ContentType == domain.SubscriptionTypeReel && msg.ContentID == "fresh" && msg.ChatID == chatID
+		})).Return(nil)
+	}
+
+	p.checkNewReelsForUser(context.Background(), "someone")
+}
+
+func TestCheckNewReelsForUserBaseline(t *testing.T) {
+	p, m := newTestParser(t)
+
+	m.instagram.EXPECT().GetUserReels(gomock.Any(), "someone").Return(testReelItems, nil)
+	m.posts.EXPECT().GetLatestByUsername(gomock.Any(), "someone", 1).Return(nil, nil)
+	for _, item := range testReelItems {
+		m.posts.EXPECT().Create(gomock.Any(), domain.PostParser{
+			PostID:   item.ID,
+			Username: "someone",
+			PostURL:  item.PostURL,
+			Source:   domain.PostSourceReel,
+			Baseline: true,
+		}).Return(nil)
+	}
+
+	p.checkNewReelsForUser(context.Background(), "someone")
+}
+
+func TestSendReelToSubscriber(t *testing.T) {
+	reel := &domain.PostItem{
+		ID:       "fresh",
+		Username: "someone",
+		PostURL:  "https://www.instagram.com/reel/fresh/",
+		Media:    []domain.MediaItem{{URL: "https://cdn.example.com/fresh.mp4"}},
+	}
+	longCaption := &domain.PostItem{
+		ID:       reel.ID,
+		Username: reel.Username,
+		PostURL:  reel.PostURL,
+		Caption:  strings.Repeat("a", maxMediaCaptionLength),
+		Media:    reel.Media,
+	}
+
+	t.Run("video failure is returned", func(t *testing.T) {
+		p, m := newTestParser(t)
+
+		m.telegram.EXPECT().SendMedia(int64(1), gomock.Any(), gomock.Any()).Return(0, errors.New("file too large"))
+
+		if _, err := p.sendReelToSubscriber(1, reel); err == nil {
+			t.Fatal("sendReelToSubscriber() error = nil, want the video error")
+		}
+	})
+
+	t.Run("sent as video with caption", func(t *testing.T) {
+		p, m := newTestParser(t)
+
+		m.telegram.EXPECT().SendMedia(int64(1), gomock.Cond(func(item domain.MediaItem) bool {
+			return item.IsVideo()
+		}), gomock.Cond(func(caption string) bool {
+			return strings.Contains(caption, reel.PostURL)
+		})).Return(7, nil)
+
+		messageID, err := p.sendReelToSubscriber(1, reel)
+		if err != nil || messageID != 7 {
+			t.Fatalf("sendReelToSubscriber() = %d, %v, want 7, nil", messageID, err)
+		}
+	})
+
+	t.Run("long caption follows the video", func(t *testing.T) {
+		p, m := newTestParser(t)
+
+		m.telegram.EXPECT().SendMedia(int64(1), gomock.Any(), "").Return(7, nil)
+		m.telegram.EXPECT().SendMessage(int64(1), gomock.Any()).Return(0, errors.New("flood control"))
+
+		messageID, err := p.sendReelToSubscriber(1, longCaption)
+		if err != nil || messageID != 7 {
+			t.Fatalf("sendReelToSubscriber() = %d, %v, want 7, nil once the video is sent", messageID, err)
+		}
+	})
+}
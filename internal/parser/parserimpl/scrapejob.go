@@ -0,0 +1,175 @@
+package paserimpl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/scrapejob"
+	apperrors "github.com/orgball2608/insta-parser-telegram-bot/pkg/errors"
+)
+
+const (
+	// Visibility timeouts of the scrape jobs. Walking every highlight album is slow, so
+	// highlight checks get more time than the others.
+	storyJobTimeout     = 10 * time.Minute
+	postJobTimeout      = 10 * time.Minute
+	snapshotJobTimeout  = 15 * time.Minute
+	highlightJobTimeout = 30 * time.Minute
+
+	// scrapeJobPollInterval is how long an idle worker waits before looking for work again
+	scrapeJobPollInterval = 5 * time.Second
+	// scrapeJobBaseBackoff is the delay after the first failed attempt; it doubles with every
+	// further attempt up to scrapeJobMaxBackoff
+	scrapeJobBaseBackoff = 2 * time.Minute
+	scrapeJobMaxBackoff  = time.Hour
+)
+
+func scrapeJobTimeout(jobType string) time.Duration {
+	if jobType == domain.SubscriptionTypeHighlight {
+		return highlightJobTimeout
+	}
+	if jobType == domain.SubscriptionTypePost {
+		return postJobTimeout
+	}
+	if jobType == domain.ScrapeJobTypeSnapshot {
+		return snapshotJobTimeout
+	}
+	return storyJobTimeout
+}
+
+// enqueueScrapeJobs queues a check of each username; accounts whose previous check is still
+// queued or running are skipped.
+func (p *ParserImpl) enqueueScrapeJobs(ctx context.Context, jobType string, usernames []string) {
+	var queued int
+	for _, username := range usernames {
+		added, err := p.ScrapeJobRepo.Enqueue(ctx, jobType, username, scrapeJobTimeout(jobType))
+		if err != nil {
+			p.Logger.Error("Failed to queue scrape job", "type", jobType, "username", username, "error", err)
+			continue
+		}
+		if added {
+			queued++
+		}
+	}
+	p.Logger.Info("Queued scrape jobs", "type", jobType, "accounts", len(usernames), "queued", queued)
+}
+
+// RunScrapeWorkers runs the workers that take jobs from the scrape queue until ctx is done.
+func (p *ParserImpl) RunScrapeWorkers(ctx context.Context) error {
+	workers := p.Config.Parser.ScrapeWorkers
+	if workers < 1 {
+		workers = 1
+	}
+	p.Logger.Info("Starting scrape workers", "count", workers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.scrapeWorker(ctx)
+		}()
+	}
+
+	wg.Wait()
+	p.Logger.Info("Scrape workers stopped")
+	return nil
+}
+
+func (p *ParserImpl) scrapeWorker(ctx context.Context) {
+	for ctx.Err() == nil {
+		job, err := p.ScrapeJobRepo.Claim(ctx)
+		if err != nil {
+			if !errors.Is(err, scrapejob.ErrNoJobAvailable) && ctx.Err() == nil {
+				p.Logger.Error("Failed to claim scrape job", "error", err)
+			}
+			select {
+			case <-ctx.Done():
+			case <-time.After(scrapeJobPollInterval):
+			}
+			continue
+		}
+
+		p.runScrapeJob(ctx, job)
+		time.Sleep(time.Duration(1+rand.Intn(3)) * time.Second)
+	}
+}
+
+// runScrapeJob executes a claimed job and completes, reschedules or dead-letters it.
+func (p *ParserImpl) runScrapeJob(ctx context.Context, job *domain.ScrapeJob) {
+	attempt := job.Attempts + 1
+	p.Logger.Info("Running scrape job", "id", job.ID, "type", job.JobType, "username", job.Username, "attempt", attempt)
+
+	jobCtx, cancel := context.WithTimeout(ctx, job.Timeout)
+	jobErr := p.executeScrapeJob(jobCtx, job)
+	cancel()
+
+	// The bookkeeping must happen even when shutdown cancelled the job
+	updateCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if jobErr == nil {
+		p.logScrapeJobUpdate(job, "complete", p.ScrapeJobRepo.Complete(updateCtx, job.ID, job.Claim))
+		return
+	}
+
+	// Interrupted by shutdown: release the job so it runs right after the restart, without
+	// using up one of its attempts
+	if ctx.Err() != nil {
+		p.logScrapeJobUpdate(job, "release", p.ScrapeJobRepo.Release(updateCtx, job.ID, job.Claim))
+		return
+	}
+
+	// A permanent failure, such as a private or missing account, would fail the same way again
+	permanent := apperrors.IsPermanent(jobErr)
+	if permanent || attempt >= p.Config.Parser.ScrapeJobMaxAttempts {
+		p.Logger.Error("Scrape job failed for good, moving it to dead letters",
+			"id", job.ID, "type", job.JobType, "username", job.Username, "attempts", attempt, "permanent", permanent, "error", jobErr)
+		p.logScrapeJobUpdate(job, "dead-letter", p.ScrapeJobRepo.MoveToDeadLetter(updateCtx, job.ID, job.Claim, jobErr.Error()))
+		return
+	}
+
+	runAt := time.Now().Add(scrapeJobBackoff(attempt))
+	p.Logger.Warn("Scrape job failed, will retry",
+		"id", job.ID, "type", job.JobType, "username", job.Username, "attempt", attempt, "run_at", runAt, "error", jobErr)
+	p.logScrapeJobUpdate(job, "reschedule", p.ScrapeJobRepo.Retry(updateCtx, job.ID, job.Claim, jobErr.Error(), runAt))
+}
+
+// logScrapeJobUpdate logs a failed update of a job after running it. A lost claim means the
+// job ran past its visibility timeout and another worker has taken it over.
+func (p *ParserImpl) logScrapeJobUpdate(job *domain.ScrapeJob, action string, err error) {
+	if errors.Is(err, scrapejob.ErrClaimLost) {
+		p.Logger.Warn("Scrape job was claimed again by another worker, leaving it to that worker", "id", job.ID, "action", action, "claim", job.Claim)
+	} else if err != nil {
+		p.Logger.Error("Failed to update scrape job", "id", job.ID, "action", action, "error", err)
+	}
+}
+
+func (p *ParserImpl) executeScrapeJob(ctx context.Context, job *domain.ScrapeJob) error {
+	switch job.JobType {
+	case domain.SubscriptionTypeStory:
+		return p.processSubscribedUser(ctx, job.Username)
+	case domain.SubscriptionTypePost:
+		return p.checkNewPostsForUser(ctx, job.Username)
+	case domain.SubscriptionTypeHighlight:
+		return p.checkNewHighlightsForUser(ctx, job.Username)
+	case domain.ScrapeJobTypeSnapshot:
+		return p.checkSnapshotForUser(ctx, job.Username)
+	default:
+		return fmt.Errorf("unknown scrape job type %q", job.JobType)
+	}
+}
+
+// scrapeJobBackoff returns the delay before the next attempt after the given number of attempts
+func scrapeJobBackoff(attempts int) time.Duration {
+	backoff := scrapeJobBaseBackoff
+	for i := 1; i < attempts && backoff < scrapeJobMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, scrapeJobMaxBackoff)
+}
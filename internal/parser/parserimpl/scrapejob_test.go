@@ -0,0 +1,64 @@
+package paserimpl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/scrapejob"
+	"go.uber.org/mock/gomock"
+)
+
+// failingJob returns a claimed job that fails with an unknown job type error.
+func failingJob(attempts int) *domain.ScrapeJob {
+	return &domain.ScrapeJob{ID: 1, JobType: "unknown", Username: "someone", Attempts: attempts, Claim: 4, Timeout: time.Minute}
+}
+
+func TestRunScrapeJobRetriesFailure(t *testing.T) {
+	p, m := newTestParser(t)
+	p.Config.Parser.ScrapeJobMaxAttempts = 3
+
+	before := time.Now()
+	m.scrapeJobs.EXPECT().Retry(gomock.Any(), 1, 4, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _, _ int, _ string, runAt time.Time) error {
+			// The second attempt failed, so the backoff has doubled once
+			if runAt.Before(before.Add(2 * scrapeJobBaseBackoff)) {
+				t.Errorf("retry runs at %s, want the backoff of a second attempt", runAt)
+			}
+			return nil
+		})
+
+	p.runScrapeJob(context.Background(), failingJob(1))
+}
+
+func TestRunScrapeJobDeadLettersLastAttempt(t *testing.T) {
+	p, m := newTestParser(t)
+	p.Config.Parser.ScrapeJobMaxAttempts = 3
+
+	m.scrapeJobs.EXPECT().MoveToDeadLetter(gomock.Any(), 1, 4, gomock.Any()).Return(nil)
+
+	p.runScrapeJob(context.Background(), failingJob(2))
+}
+
+func TestRunScrapeJobReleasesOnShutdown(t *testing.T) {
+	p, m := newTestParser(t)
+	p.Config.Parser.ScrapeJobMaxAttempts = 3
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	// Released without counting an attempt, even on what would be its last one
+	m.scrapeJobs.EXPECT().Release(gomock.Any(), 1, 4).Return(nil)
+
+	p.runScrapeJob(ctx, failingJob(2))
+}
+
+func TestRunScrapeJobLostClaim(t *testing.T) {
+	p, m := newTestParser(t)
+	p.Config.Parser.ScrapeJobMaxAttempts = 3
+
+	// Another worker reclaimed the job, so it is left alone
+	m.scrapeJobs.EXPECT().Retry(gomock.Any(), 1, 4, gomock.Any(), gomock.Any()).Return(scrapejob.ErrClaimLost)
+
+	p.runScrapeJob(context.Background(), failingJob(0))
+}
@@ -0,0 +1,74 @@
+package paserimpl
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+)
+
+// checkSnapshotForUser checks the stories and posts of username from one profile visit. The
+// profile header and highlight previews come with it, so profile subscribers get a change
+// check and new highlight albums trigger a highlight walk without waiting for its schedule.
+func (p *ParserImpl) checkSnapshotForUser(ctx context.Context, username string) error {
+	snapshot, err := p.Instagram.GetProfileSnapshot(ctx, username)
+	if err != nil {
+		return fmt.Errorf("failed to get profile snapshot of %s: %w", username, err)
+	}
+
+	if snapshot.Profile.IsPrivate {
+		p.Logger.Warn("Account is private, skipping its stories and posts", "username", username)
+	}
+
+	if err := p.queueNewStories(ctx, username, snapshot.Stories); err != nil {
+		return err
+	}
+	if err := p.queueNewPosts(ctx, username, snapshot.Posts); err != nil {
+		return err
+	}
+
+	profileSubscribers, err := p.SubscriptionRepo.GetSubscribersForUserByType(ctx, username, domain.SubscriptionTypeProfile)
+	if err != nil {
+		p.Logger.Error("Failed to get profile subscribers", "username", username, "error", err)
+	} else if len(profileSubscribers) > 0 {
+		p.compareProfile(ctx, username, &snapshot.Profile)
+	}
+
+	p.checkHighlightPreviews(ctx, username, snapshot.Highlights)
+	return nil
+}
+
+// checkHighlightPreviews queues a highlight walk of username when the snapshot lists an album
+// none of its tracked items were found in.
+func (p *ParserImpl) checkHighlightPreviews(ctx context.Context, username string, previews []domain.HighlightAlbumPreview) {
+	if previews == nil {
+		return
+	}
+
+	subscribers, err := p.SubscriptionRepo.GetSubscribersForUserByType(ctx, username, domain.SubscriptionTypeHighlight)
+	if err != nil {
+		p.Logger.Error("Failed to get highlight subscribers", "username", username, "error", err)
+		return
+	}
+	if len(subscribers) == 0 {
+		return
+	}
+
+	// Accounts that were never walked are left to the scheduled walk, which records them first
+	known, err := p.HighlightsRepo.GetTrackedAlbumIDs(ctx, username)
+	if err != nil {
+		p.Logger.Error("Failed to get tracked highlight albums", "username", username, "error", err)
+		return
+	}
+	if len(known) == 0 {
+		return
+	}
+
+	for _, preview := range previews {
+		if !known[preview.ID] {
+			p.Logger.Info("Snapshot lists a new highlight album, queueing a highlight check", "username", username, "album", preview.Title)
+			p.enqueueScrapeJobs(ctx, domain.SubscriptionTypeHighlight, []string{username})
+			return
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package paserimpl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/story"
+	"go.uber.org/mock/gomock"
+)
+
+func TestQueueNewStoriesSkipsPostOnlySubscribers(t *testing.T) {
+	p, m := newTestParser(t)
+
+	const storyChat, postChat int64 = 1, 2
+	m.subscriptions.EXPECT().GetSubscribersForUserByType(gomock.Any(), "someone", domain.SubscriptionTypeStory).Return([]int64{storyChat}, nil)
+	// Asking for post subscribers would reach the post-only chat
+	m.subscriptions.EXPECT().GetSubscribersForUserByType(gomock.Any(), "someone", domain.SubscriptionTypePost).Return([]int64{postChat}, nil).AnyTimes()
+
+	m.stories.EXPECT().GetByStoryID(gomock.Any(), "s1").Return(nil, story.ErrNotFound)
+	m.deliveries.EXPECT().GetByContentID(gomock.Any(), "s1").Return(nil, nil)
+	m.stories.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	m.outbox.EXPECT().Enqueue(gomock.Any(), gomock.Cond(func(msg domain.OutboxMessage) bool {
+		return msg.ContentType == domain.SubscriptionTypeStory && msg.ContentID == "s1" && msg.ChatID == storyChat
+	})).Return(nil)
+
+	err := p.queueNewStories(context.Background(), "someone", []domain.StoryItem{{ID: "s1", Username: "someone"}})
+	if err != nil {
+		t.Fatalf("queueNewStories() error = %v", err)
+	}
+}
+
+func TestQueueNewStoriesSkipsDeliveredChats(t *testing.T) {
+	p, m := newTestParser(t)
+
+	m.subscriptions.EXPECT().GetSubscribersForUserByType(gomock.Any(), "someone", domain.SubscriptionTypeStory).Return([]int64{1, 2}, nil)
+	m.stories.EXPECT().GetByStoryID(gomock.Any(), "s1").Return(&domain.Story{StoryID: "s1"}, nil)
+	m.deliveries.EXPECT().GetByContentID(gomock.Any(), "s1").Return(map[int64]*domain.Delivery{1: {ChatID: 1}}, nil)
+	// Only the chat that subscribed after the story was first seen gets it
+	m.outbox.EXPECT().Enqueue(gomock.Any(), gomock.Cond(func(msg domain.OutboxMessage) bool {
+		return msg.ChatID == 2
+	})).Return(nil)
+
+	err := p.queueNewStories(context.Background(), "someone", []domain.StoryItem{{ID: "s1", Username: "someone"}})
+	if err != nil {
+		t.Fatalf("queueNewStories() error = %v", err)
+	}
+}
@@ -0,0 +1,19 @@
+package accountpoll
+
+import (
+	"context"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+)
+
+//go:generate go run go.uber.org/mock/mockgen -source=accountpoll.go -destination=mocks/mock.go
+type Repository interface {
+	// GetByJobType returns the polling state of every account polled for jobType
+	GetByJobType(ctx context.Context, jobType string) ([]*domain.AccountPoll, error)
+	// GetAll returns the polling state of every account, soonest next poll first
+	GetAll(ctx context.Context) ([]*domain.AccountPoll, error)
+	// Save creates or replaces the polling state of an account
+	Save(ctx context.Context, poll domain.AccountPoll) error
+	// Delete removes the polling state of an account that is no longer subscribed
+	Delete(ctx context.Context, jobType, username string) error
+}
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: accountpoll.go
+//
+// Generated by this command:
+//
+//	mockgen -source=accountpoll.go -destination=mocks/mock.go
+//
+
+// Package mock_accountpoll is a generated GoMock package.
+package mock_accountpoll
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockRepository) Delete(ctx context.Context, jobType, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, jobType, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder) Delete(ctx, jobType, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, jobType, username)
+}
+
+// GetAll mocks base method.
+func (m *MockRepository) GetAll(ctx context.Context) ([]*domain.AccountPoll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]*domain.AccountPoll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockRepositoryMockRecorder) GetAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockRepository)(nil).GetAll), ctx)
+}
+
+// GetByJobType mocks base method.
+func (m *MockRepository) GetByJobType(ctx context.Context, jobType string) ([]*domain.AccountPoll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByJobType", ctx, jobType)
+	ret0, _ := ret[0].([]*domain.AccountPoll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByJobType indicates an expected call of GetByJobType.
+func (mr *MockRepositoryMockRecorder) GetByJobType(ctx, jobType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByJobType", reflect.TypeOf((*MockRepository)(nil).GetByJobType), ctx, jobType)
+}
+
+// Save mocks base method.
+func (m *MockRepository) Save(ctx context.Context, poll domain.AccountPoll) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, poll)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockRepositoryMockRecorder) Save(ctx, poll any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockRepository)(nil).Save), ctx, poll)
+}
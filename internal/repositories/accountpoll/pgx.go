@@ -0,0 +1,132 @@
+package accountpoll
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/logger"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+type PgxRepository struct {
+	pool   *pgxpool.Pool
+	logger logger.Logger
+}
+
+func NewPgxRepository(pool *pgxpool.Pool, logger logger.Logger) *PgxRepository {
+	return &PgxRepository{
+		pool:   pool,
+		logger: logger.WithComponent("AccountPollRepo"),
+	}
+}
+
+var _ Repository = (*PgxRepository)(nil)
+
+var pollColumns = []string{
+	"username", "job_type", "interval_seconds", "items_seen", "last_polled_at", "next_poll_at", "updated_at",
+}
+
+func (r *PgxRepository) GetByJobType(ctx context.Context, jobType string) ([]*domain.AccountPoll, error) {
+	query, args, err := repositories.SqBuilder.
+		Select(pollColumns...).
+		From("account_polls").
+		Where(sq.Eq{"job_type": jobType}).
+		ToSql()
+	if err != nil {
+		return nil, repositories.ErrBadQuery
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s account polls: %w", jobType, err)
+	}
+
+	return scanPolls(rows)
+}
+
+func (r *PgxRepository) GetAll(ctx context.Context) ([]*domain.AccountPoll, error) {
+	query, args, err := repositories.SqBuilder.
+		Select(pollColumns...).
+		From("account_polls").
+		OrderBy("next_poll_at", "username", "job_type").
+		ToSql()
+	if err != nil {
+		return nil, repositories.ErrBadQuery
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account polls: %w", err)
+	}
+
+	return scanPolls(rows)
+}
+
+func (r *PgxRepository) Save(ctx context.Context, poll domain.AccountPoll) error {
+	query, args, err := repositories.SqBuilder.
+		Insert("account_polls").
+		Columns(pollColumns...).
+		Values(poll.Username, poll.JobType, int(poll.Interval.Seconds()), poll.ItemsSeen, poll.LastPolledAt, poll.NextPollAt, time.Now()).
+		Suffix(`ON CONFLICT (username, job_type) DO UPDATE SET
+			interval_seconds = EXCLUDED.interval_seconds,
+			items_seen = EXCLUDED.items_seen,
+			last_polled_at = EXCLUDED.last_polled_at,
+			next_poll_at = EXCLUDED.next_poll_at,
+			updated_at = EXCLUDED.updated_at`).
+		ToSql()
+	if err != nil {
+		return repositories.ErrBadQuery
+	}
+
+	if _, err := r.pool.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to save %s account poll for %s: %w", poll.JobType, poll.Username, err)
+	}
+
+	return nil
+}
+
+func (r *PgxRepository) Delete(ctx context.Context, jobType, username string) error {
+	query, args, err := repositories.SqBuilder.
+		Delete("account_polls").
+		Where(sq.Eq{"job_type": jobType, "username": username}).
+		ToSql()
+	if err != nil {
+		return repositories.ErrBadQuery
+	}
+
+	if _, err := r.pool.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to delete %s account poll for %s: %w", jobType, username, err)
+	}
+
+	return nil
+}
+
+func scanPolls(rows pgx.Rows) ([]*domain.AccountPoll, error) {
+	defer rows.Close()
+
+	var polls []*domain.AccountPoll
+	for rows.Next() {
+		var poll domain.AccountPoll
+		var intervalSeconds int
+		if err := rows.Scan(
+			&poll.Username, &poll.JobType, &intervalSeconds, &poll.ItemsSeen,
+			&poll.LastPolledAt, &poll.NextPollAt, &poll.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		poll.Interval = time.Duration(intervalSeconds) * time.Second
+		polls = append(polls, &poll)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return polls, nil
+}
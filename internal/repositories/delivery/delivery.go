@@ -0,0 +1,20 @@
+package delivery
+
+import (
+	"context"
+	"time"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+)
+
+//go:generate go run go.uber.org/mock/mockgen -source=delivery.go -destination=mocks/mock.go
+type Repository interface {
+	// GetByContentID returns the deliveries of a piece of content, keyed by chat ID
+	GetByContentID(ctx context.Context, contentID string) (map[int64]*domain.Delivery, error)
+
+	// RecordAttempt stores the outcome of one send attempt, counting it towards the attempts
+	RecordAttempt(ctx context.Context, delivery domain.Delivery) error
+
+	// CleanupOldRecords deletes deliveries created before the given age
+	CleanupOldRecords(ctx context.Context, olderThan time.Duration) (int64, error)
+}
@@ -0,0 +1,12 @@
+package delivery
+
+import (
+	"go.uber.org/fx"
+)
+
+var Module = fx.Provide(
+	fx.Annotate(
+		NewPgxRepository,
+		fx.As(new(Repository)),
+	),
+)
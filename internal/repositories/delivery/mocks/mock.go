@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: delivery.go
+//
+// Generated by this command:
+//
+//	mockgen -source=delivery.go -destination=mocks/mock.go
+//
+
+// Package mock_delivery is a generated GoMock package.
+package mock_delivery
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CleanupOldRecords mocks base method.
+func (m *MockRepository) CleanupOldRecords(ctx context.Context, olderThan time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanupOldRecords", ctx, olderThan)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CleanupOldRecords indicates an expected call of CleanupOldRecords.
+func (mr *MockRepositoryMockRecorder) CleanupOldRecords(ctx, olderThan any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanupOldRecords", reflect.TypeOf((*MockRepository)(nil).CleanupOldRecords), ctx, olderThan)
+}
+
+// GetByContentID mocks base method.
+func (m *MockRepository) GetByContentID(ctx context.Context, contentID string) (map[int64]*domain.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByContentID", ctx, contentID)
+	ret0, _ := ret[0].(map[int64]*domain.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByContentID indicates an expected call of GetByContentID.
+func (mr *MockRepositoryMockRecorder) GetByContentID(ctx, contentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByContentID", reflect.TypeOf((*MockRepository)(nil).GetByContentID), ctx, contentID)
+}
+
+// RecordAttempt mocks base method.
+func (m *MockRepository) RecordAttempt(ctx context.Context, delivery domain.Delivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordAttempt", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordAttempt indicates an expected call of RecordAttempt.
+func (mr *MockRepositoryMockRecorder) RecordAttempt(ctx, delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordAttempt", reflect.TypeOf((*MockRepository)(nil).RecordAttempt), ctx, delivery)
+}
@@ -0,0 +1,104 @@
+package delivery
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/logger"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+type PgxRepository struct {
+	pool   *pgxpool.Pool
+	logger logger.Logger
+}
+
+func NewPgxRepository(pool *pgxpool.Pool, logger logger.Logger) *PgxRepository {
+	return &PgxRepository{
+		pool:   pool,
+		logger: logger.WithComponent("DeliveryRepo"),
+	}
+}
+
+var _ Repository = (*PgxRepository)(nil)
+
+func (r *PgxRepository) GetByContentID(ctx context.Context, contentID string) (map[int64]*domain.Delivery, error) {
+	query, args, err := repositories.SqBuilder.
+		Select("id", "content_type", "content_id", "chat_id", "status", "attempts", "message_id", "last_error", "created_at", "updated_at").
+		From("deliveries").
+		Where(sq.Eq{"content_id": contentID}).
+		ToSql()
+	if err != nil {
+		return nil, repositories.ErrBadQuery
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query deliveries of %s: %w", contentID, err)
+	}
+	defer rows.Close()
+
+	deliveries := make(map[int64]*domain.Delivery)
+	for rows.Next() {
+		var d domain.Delivery
+		if err := rows.Scan(&d.ID, &d.ContentType, &d.ContentID, &d.ChatID, &d.Status, &d.Attempts, &d.MessageID, &d.LastError, &d.CreatedAt, &d.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan delivery row: %w", err)
+		}
+		deliveries[d.ChatID] = &d
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating delivery rows: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func (r *PgxRepository) RecordAttempt(ctx context.Context, delivery domain.Delivery) error {
+	query := `
+		INSERT INTO deliveries (content_type, content_id, chat_id, status, attempts, message_id, last_error)
+		VALUES ($1, $2, $3, $4, 1, $5, $6)
+		ON CONFLICT (content_id, chat_id) DO UPDATE
+		SET status = EXCLUDED.status,
+		    attempts = deliveries.attempts + 1,
+		    message_id = EXCLUDED.message_id,
+		    last_error = EXCLUDED.last_error,
+		    updated_at = NOW()
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		delivery.ContentType,
+		delivery.ContentID,
+		delivery.ChatID,
+		delivery.Status,
+		delivery.MessageID,
+		delivery.LastError,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record delivery of %s to %d: %w", delivery.ContentID, delivery.ChatID, err)
+	}
+
+	return nil
+}
+
+func (r *PgxRepository) CleanupOldRecords(ctx context.Context, olderThan time.Duration) (int64, error) {
+	query, args, err := repositories.SqBuilder.
+		Delete("deliveries").
+		Where(sq.Lt{"created_at": time.Now().Add(-olderThan)}).
+		ToSql()
+	if err != nil {
+		return 0, repositories.ErrBadQuery
+	}
+
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete old deliveries: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
@@ -1,9 +1,16 @@
 package fx
 
 import (
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/accountpoll"
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/currentstory"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/delivery"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/highlightalbum"
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/highlights"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/outbox"
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/post"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/profilesnapshot"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/scrapejob"
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/story"
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories/subscription"
 	"go.uber.org/fx"
@@ -12,7 +19,19 @@ import (
 var Module = fx.Options(
 	story.Module,
 	highlights.Module,
+	highlightalbum.Module,
 	currentstory.Module,
 	subscription.Module,
 	post.Module,
+	profilesnapshot.Module,
+	delivery.Module,
+	outbox.Module,
+	scrapejob.Module,
+	accountpoll.Module,
+	fx.Provide(
+		fx.Annotate(
+			repositories.NewPgxTransactor,
+			fx.As(new(repositories.Transactor)),
+		),
+	),
 )
@@ -0,0 +1,12 @@
+package highlightalbum
+
+import (
+	"go.uber.org/fx"
+)
+
+var Module = fx.Provide(
+	fx.Annotate(
+		NewPgxRepository,
+		fx.As(new(Repository)),
+	),
+)
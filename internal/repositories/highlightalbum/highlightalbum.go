@@ -0,0 +1,20 @@
+package highlightalbum
+
+import (
+	"context"
+	"errors"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+)
+
+var ErrNotFound = errors.New("highlight album not found")
+
+//go:generate go run go.uber.org/mock/mockgen -source=highlightalbum.go -destination=mocks/mock.go
+type Repository interface {
+	// Upsert records a listed album, refreshing its title, cover and last seen time, and
+	// returns its serial ID
+	Upsert(ctx context.Context, album domain.HighlightAlbum) (int, error)
+
+	// GetByID returns the album with the given serial ID
+	GetByID(ctx context.Context, id int) (*domain.HighlightAlbum, error)
+}
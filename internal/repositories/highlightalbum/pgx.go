@@ -0,0 +1,71 @@
+package highlightalbum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/logger"
+)
+
+type PgxRepository struct {
+	pool   *pgxpool.Pool
+	logger logger.Logger
+}
+
+func NewPgxRepository(pool *pgxpool.Pool, logger logger.Logger) *PgxRepository {
+	return &PgxRepository{
+		pool:   pool,
+		logger: logger.WithComponent("HighlightAlbumRepo"),
+	}
+}
+
+var _ Repository = (*PgxRepository)(nil)
+
+func (r *PgxRepository) Upsert(ctx context.Context, album domain.HighlightAlbum) (int, error) {
+	query := `
+		INSERT INTO highlight_albums (username, album_id, title, cover_url)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (username, album_id) DO UPDATE
+		SET title = EXCLUDED.title, cover_url = EXCLUDED.cover_url, last_seen_at = NOW()
+		RETURNING id
+	`
+
+	var id int
+	err := r.pool.QueryRow(ctx, query, album.Username, album.AlbumID, album.Title, album.CoverURL).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to upsert highlight album %s of %s: %w", album.AlbumID, album.Username, err)
+	}
+
+	return id, nil
+}
+
+func (r *PgxRepository) GetByID(ctx context.Context, id int) (*domain.HighlightAlbum, error) {
+	query := `
+		SELECT id, username, album_id, title, cover_url, created_at, last_seen_at
+		FROM highlight_albums
+		WHERE id = $1
+	`
+
+	var album domain.HighlightAlbum
+	err := r.pool.QueryRow(ctx, query, id).Scan(
+		&album.ID,
+		&album.Username,
+		&album.AlbumID,
+		&album.Title,
+		&album.CoverURL,
+		&album.CreatedAt,
+		&album.LastSeenAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get highlight album by id: %w", err)
+	}
+
+	return &album, nil
+}
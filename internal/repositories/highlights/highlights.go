@@ -17,6 +17,7 @@ type Highlights struct {
 
 var ErrNotFound = errors.New("highlights not found")
 var ErrCannotCreate = errors.New("error create highlights")
+var ErrAlreadyExists = errors.New("highlight item already tracked")
 
 //go:generate go run go.uber.org/mock/mockgen -source=highlights.go -destination=mocks/mock.go
 
@@ -24,4 +25,8 @@ type Repository interface {
 	GetByID(ctx context.Context, id int) (*domain.Highlights, error)
 	GetByUserName(ctx context.Context, userName string) ([]*domain.Highlights, error)
 	Create(ctx context.Context, highlights domain.Highlights) error
+	// GetTrackedItemIDs returns the IDs of the items recorded by highlight subscriptions for a user
+	GetTrackedItemIDs(ctx context.Context, userName string) (map[string]bool, error)
+	// GetTrackedAlbumIDs returns the IDs of the albums the tracked items of a user were found in
+	GetTrackedAlbumIDs(ctx context.Context, userName string) (map[string]bool, error)
 }
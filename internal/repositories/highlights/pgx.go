@@ -9,6 +9,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories"
 	"github.com/orgball2608/insta-parser-telegram-bot/pkg/logger"
 )
 
@@ -26,7 +27,7 @@ func NewPgxRepository(pool *pgxpool.Pool, logger logger.Logger) *PgxRepository {
 
 func (r *PgxRepository) GetByID(ctx context.Context, id int) (*domain.Highlights, error) {
 	query := `
-		SELECT id, username, media_url, created_at
+		SELECT id, username, media_url, album_id, item_id, created_at
 		FROM highlights
 		WHERE id = $1
 	`
@@ -36,6 +37,8 @@ func (r *PgxRepository) GetByID(ctx context.Context, id int) (*domain.Highlights
 		&highlights.ID,
 		&highlights.UserName,
 		&highlights.MediaURL,
+		&highlights.AlbumID,
+		&highlights.ItemID,
 		&highlights.CreatedAt,
 	)
 	if err != nil {
@@ -50,7 +53,7 @@ func (r *PgxRepository) GetByID(ctx context.Context, id int) (*domain.Highlights
 
 func (r *PgxRepository) GetByUserName(ctx context.Context, userName string) ([]*domain.Highlights, error) {
 	query := `
-		SELECT id, username, media_url, created_at
+		SELECT id, username, media_url, album_id, item_id, created_at
 		FROM highlights
 		WHERE username = $1
 		ORDER BY created_at DESC
@@ -69,6 +72,8 @@ func (r *PgxRepository) GetByUserName(ctx context.Context, userName string) ([]*
 			&highlights.ID,
 			&highlights.UserName,
 			&highlights.MediaURL,
+			&highlights.AlbumID,
+			&highlights.ItemID,
 			&highlights.CreatedAt,
 		)
 		if err != nil {
@@ -90,25 +95,90 @@ func (r *PgxRepository) GetByUserName(ctx context.Context, userName string) ([]*
 
 func (r *PgxRepository) Create(ctx context.Context, highlights domain.Highlights) error {
 	query := `
-		INSERT INTO highlights (username, media_url, created_at)
-		VALUES ($1, $2, $3)
+		INSERT INTO highlights (username, media_url, album_id, item_id, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT DO NOTHING
 		RETURNING id
 	`
 
+	// A conflict returns no row instead of failing, so a surrounding transaction stays usable
 	var id int
-	err := r.pool.QueryRow(
+	err := repositories.Conn(ctx, r.pool).QueryRow(
 		ctx,
 		query,
 		highlights.UserName,
 		highlights.MediaURL,
+		highlights.AlbumID,
+		highlights.ItemID,
 		time.Now(),
 	).Scan(&id)
 
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrAlreadyExists
+		}
 		return fmt.Errorf("failed to create highlights: %w", err)
 	}
 
 	return nil
 }
 
+func (r *PgxRepository) GetTrackedItemIDs(ctx context.Context, userName string) (map[string]bool, error) {
+	query := `
+		SELECT item_id
+		FROM highlights
+		WHERE username = $1 AND item_id <> ''
+	`
+
+	rows, err := r.pool.Query(ctx, query, userName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tracked highlight items: %w", err)
+	}
+	defer rows.Close()
+
+	itemIDs := make(map[string]bool)
+	for rows.Next() {
+		var itemID string
+		if err := rows.Scan(&itemID); err != nil {
+			return nil, fmt.Errorf("failed to scan tracked highlight item: %w", err)
+		}
+		itemIDs[itemID] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tracked highlight items: %w", err)
+	}
+
+	return itemIDs, nil
+}
+
+func (r *PgxRepository) GetTrackedAlbumIDs(ctx context.Context, userName string) (map[string]bool, error) {
+	query := `
+		SELECT DISTINCT album_id
+		FROM highlights
+		WHERE username = $1 AND album_id <> ''
+	`
+
+	rows, err := r.pool.Query(ctx, query, userName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tracked highlight albums: %w", err)
+	}
+	defer rows.Close()
+
+	albumIDs := make(map[string]bool)
+	for rows.Next() {
+		var albumID string
+		if err := rows.Scan(&albumID); err != nil {
+			return nil, fmt.Errorf("failed to scan tracked highlight album: %w", err)
+		}
+		albumIDs[albumID] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tracked highlight albums: %w", err)
+	}
+
+	return albumIDs, nil
+}
+
 var _ Repository = (*PgxRepository)(nil)
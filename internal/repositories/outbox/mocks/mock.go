@@ -0,0 +1,159 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: outbox.go
+//
+// Generated by this command:
+//
+//	mockgen -source=outbox.go -destination=mocks/mock.go
+//
+
+// Package mock_outbox is a generated GoMock package.
+package mock_outbox
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CleanupOldRecords mocks base method.
+func (m *MockRepository) CleanupOldRecords(ctx context.Context, status string, olderThan time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanupOldRecords", ctx, status, olderThan)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CleanupOldRecords indicates an expected call of CleanupOldRecords.
+func (mr *MockRepositoryMockRecorder) CleanupOldRecords(ctx, status, olderThan any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanupOldRecords", reflect.TypeOf((*MockRepository)(nil).CleanupOldRecords), ctx, status, olderThan)
+}
+
+// CountByStatus mocks base method.
+func (m *MockRepository) CountByStatus(ctx context.Context) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByStatus", ctx)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByStatus indicates an expected call of CountByStatus.
+func (mr *MockRepositoryMockRecorder) CountByStatus(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByStatus", reflect.TypeOf((*MockRepository)(nil).CountByStatus), ctx)
+}
+
+// Enqueue mocks base method.
+func (m *MockRepository) Enqueue(ctx context.Context, msg domain.OutboxMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", ctx, msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockRepositoryMockRecorder) Enqueue(ctx, msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockRepository)(nil).Enqueue), ctx, msg)
+}
+
+// GetDead mocks base method.
+func (m *MockRepository) GetDead(ctx context.Context, limit int) ([]*domain.OutboxMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDead", ctx, limit)
+	ret0, _ := ret[0].([]*domain.OutboxMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDead indicates an expected call of GetDead.
+func (mr *MockRepositoryMockRecorder) GetDead(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDead", reflect.TypeOf((*MockRepository)(nil).GetDead), ctx, limit)
+}
+
+// GetDue mocks base method.
+func (m *MockRepository) GetDue(ctx context.Context, limit int) ([]*domain.OutboxMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDue", ctx, limit)
+	ret0, _ := ret[0].([]*domain.OutboxMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDue indicates an expected call of GetDue.
+func (mr *MockRepositoryMockRecorder) GetDue(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDue", reflect.TypeOf((*MockRepository)(nil).GetDue), ctx, limit)
+}
+
+// MarkFailed mocks base method.
+func (m *MockRepository) MarkFailed(ctx context.Context, id int, lastError string, nextAttemptAt time.Time, dead bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFailed", ctx, id, lastError, nextAttemptAt, dead)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkFailed indicates an expected call of MarkFailed.
+func (mr *MockRepositoryMockRecorder) MarkFailed(ctx, id, lastError, nextAttemptAt, dead any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFailed", reflect.TypeOf((*MockRepository)(nil).MarkFailed), ctx, id, lastError, nextAttemptAt, dead)
+}
+
+// MarkSent mocks base method.
+func (m *MockRepository) MarkSent(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSent", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkSent indicates an expected call of MarkSent.
+func (mr *MockRepositoryMockRecorder) MarkSent(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSent", reflect.TypeOf((*MockRepository)(nil).MarkSent), ctx, id)
+}
+
+// Requeue mocks base method.
+func (m *MockRepository) Requeue(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Requeue", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Requeue indicates an expected call of Requeue.
+func (mr *MockRepositoryMockRecorder) Requeue(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Requeue", reflect.TypeOf((*MockRepository)(nil).Requeue), ctx, id)
+}
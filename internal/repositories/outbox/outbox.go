@@ -0,0 +1,35 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+)
+
+var ErrNotFound = errors.New("outbox message not found")
+
+//go:generate go run go.uber.org/mock/mockgen -source=outbox.go -destination=mocks/mock.go
+type Repository interface {
+	// Enqueue adds a pending message; a message for the same content and chat is kept as is.
+	// It joins the transaction carried by ctx, if any.
+	Enqueue(ctx context.Context, msg domain.OutboxMessage) error
+
+	// GetDue returns up to limit pending messages whose next attempt is due, oldest first
+	GetDue(ctx context.Context, limit int) ([]*domain.OutboxMessage, error)
+	MarkSent(ctx context.Context, id int) error
+	// MarkFailed records a failed attempt and schedules the next one at nextAttemptAt, or
+	// moves the message to the dead-letter state when dead is true
+	MarkFailed(ctx context.Context, id int, lastError string, nextAttemptAt time.Time, dead bool) error
+
+	// GetDead returns up to limit dead messages, most recently failed first
+	GetDead(ctx context.Context, limit int) ([]*domain.OutboxMessage, error)
+	// CountByStatus returns the number of messages in each status
+	CountByStatus(ctx context.Context) (map[string]int, error)
+	// Requeue moves a dead message back to pending with its attempts reset
+	Requeue(ctx context.Context, id int) error
+
+	// CleanupOldRecords deletes messages with the given status created before the given age
+	CleanupOldRecords(ctx context.Context, status string, olderThan time.Duration) (int64, error)
+}
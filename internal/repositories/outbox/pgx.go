@@ -0,0 +1,228 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/logger"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+var outboxColumns = []string{
+	"id", "content_type", "content_id", "chat_id", "payload", "status", "attempts",
+	"next_attempt_at", "last_error", "created_at", "updated_at",
+}
+
+type PgxRepository struct {
+	pool   *pgxpool.Pool
+	logger logger.Logger
+}
+
+func NewPgxRepository(pool *pgxpool.Pool, logger logger.Logger) *PgxRepository {
+	return &PgxRepository{
+		pool:   pool,
+		logger: logger.WithComponent("OutboxRepo"),
+	}
+}
+
+var _ Repository = (*PgxRepository)(nil)
+
+func (r *PgxRepository) Enqueue(ctx context.Context, msg domain.OutboxMessage) error {
+	query, args, err := repositories.SqBuilder.
+		Insert("outbox").
+		Columns("content_type", "content_id", "chat_id", "payload", "status").
+		Values(msg.ContentType, msg.ContentID, msg.ChatID, msg.Payload, domain.OutboxStatusPending).
+		Suffix("ON CONFLICT (content_type, content_id, chat_id) DO NOTHING").
+		ToSql()
+	if err != nil {
+		return repositories.ErrBadQuery
+	}
+
+	if _, err := repositories.Conn(ctx, r.pool).Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to enqueue %s %s for %d: %w", msg.ContentType, msg.ContentID, msg.ChatID, err)
+	}
+
+	return nil
+}
+
+func (r *PgxRepository) GetDue(ctx context.Context, limit int) ([]*domain.OutboxMessage, error) {
+	query, args, err := repositories.SqBuilder.
+		Select(outboxColumns...).
+		From("outbox").
+		Where(sq.Eq{"status": domain.OutboxStatusPending}).
+		Where(sq.LtOrEq{"next_attempt_at": time.Now()}).
+		OrderBy("next_attempt_at ASC", "id ASC").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, repositories.ErrBadQuery
+	}
+
+	return r.query(ctx, query, args...)
+}
+
+func (r *PgxRepository) MarkSent(ctx context.Context, id int) error {
+	query, args, err := repositories.SqBuilder.
+		Update("outbox").
+		Set("status", domain.OutboxStatusSent).
+		Set("attempts", sq.Expr("attempts + 1")).
+		Set("last_error", "").
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return repositories.ErrBadQuery
+	}
+
+	if _, err := r.pool.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to mark outbox message %d as sent: %w", id, err)
+	}
+
+	return nil
+}
+
+func (r *PgxRepository) MarkFailed(ctx context.Context, id int, lastError string, nextAttemptAt time.Time, dead bool) error {
+	status := domain.OutboxStatusPending
+	if dead {
+		status = domain.OutboxStatusDead
+	}
+
+	query, args, err := repositories.SqBuilder.
+		Update("outbox").
+		Set("status", status).
+		Set("attempts", sq.Expr("attempts + 1")).
+		Set("last_error", lastError).
+		Set("next_attempt_at", nextAttemptAt).
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return repositories.ErrBadQuery
+	}
+
+	if _, err := r.pool.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to mark outbox message %d as failed: %w", id, err)
+	}
+
+	return nil
+}
+
+func (r *PgxRepository) GetDead(ctx context.Context, limit int) ([]*domain.OutboxMessage, error) {
+	query, args, err := repositories.SqBuilder.
+		Select(outboxColumns...).
+		From("outbox").
+		Where(sq.Eq{"status": domain.OutboxStatusDead}).
+		OrderBy("updated_at DESC").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, repositories.ErrBadQuery
+	}
+
+	return r.query(ctx, query, args...)
+}
+
+func (r *PgxRepository) CountByStatus(ctx context.Context) (map[string]int, error) {
+	query, args, err := repositories.SqBuilder.
+		Select("status", "COUNT(*)").
+		From("outbox").
+		GroupBy("status").
+		ToSql()
+	if err != nil {
+		return nil, repositories.ErrBadQuery
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count outbox messages: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox count: %w", err)
+		}
+		counts[status] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating outbox counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+func (r *PgxRepository) Requeue(ctx context.Context, id int) error {
+	query, args, err := repositories.SqBuilder.
+		Update("outbox").
+		Set("status", domain.OutboxStatusPending).
+		Set("attempts", 0).
+		Set("next_attempt_at", time.Now()).
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"id": id, "status": domain.OutboxStatusDead}).
+		ToSql()
+	if err != nil {
+		return repositories.ErrBadQuery
+	}
+
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to requeue outbox message %d: %w", id, err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *PgxRepository) CleanupOldRecords(ctx context.Context, status string, olderThan time.Duration) (int64, error) {
+	query, args, err := repositories.SqBuilder.
+		Delete("outbox").
+		Where(sq.Eq{"status": status}).
+		Where(sq.Lt{"created_at": time.Now().Add(-olderThan)}).
+		ToSql()
+	if err != nil {
+		return 0, repositories.ErrBadQuery
+	}
+
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete old %s outbox messages: %w", status, err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+func (r *PgxRepository) query(ctx context.Context, query string, args ...any) ([]*domain.OutboxMessage, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query outbox: %w", err)
+	}
+
+	defer rows.Close()
+
+	var messages []*domain.OutboxMessage
+	for rows.Next() {
+		var m domain.OutboxMessage
+		if err := rows.Scan(&m.ID, &m.ContentType, &m.ContentID, &m.ChatID, &m.Payload, &m.Status, &m.Attempts,
+			&m.NextAttemptAt, &m.LastError, &m.CreatedAt, &m.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox row: %w", err)
+		}
+		messages = append(messages, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating outbox rows: %w", err)
+	}
+
+	return messages, nil
+}
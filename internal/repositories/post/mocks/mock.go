@@ -0,0 +1,147 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: post.go
+//
+// Generated by this command:
+//
+//	mockgen -source=post.go -destination=mocks/mock.go
+//
+
+// Package mock_post is a generated GoMock package.
+package mock_post
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CleanupOldRecords mocks base method.
+func (m *MockRepository) CleanupOldRecords(ctx context.Context, olderThan string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanupOldRecords", ctx, olderThan)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CleanupOldRecords indicates an expected call of CleanupOldRecords.
+func (mr *MockRepositoryMockRecorder) CleanupOldRecords(ctx, olderThan any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanupOldRecords", reflect.TypeOf((*MockRepository)(nil).CleanupOldRecords), ctx, olderThan)
+}
+
+// CountByUsernameSince mocks base method.
+func (m *MockRepository) CountByUsernameSince(ctx context.Context, username string, since time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByUsernameSince", ctx, username, since)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByUsernameSince indicates an expected call of CountByUsernameSince.
+func (mr *MockRepositoryMockRecorder) CountByUsernameSince(ctx, username, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByUsernameSince", reflect.TypeOf((*MockRepository)(nil).CountByUsernameSince), ctx, username, since)
+}
+
+// Create mocks base method.
+func (m *MockRepository) Create(ctx context.Context, post domain.PostParser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, post)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder) Create(ctx, post any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, post)
+}
+
+// Exists mocks base method.
+func (m *MockRepository) Exists(ctx context.Context, postID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists", ctx, postID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockRepositoryMockRecorder) Exists(ctx, postID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockRepository)(nil).Exists), ctx, postID)
+}
+
+// GetByUsername mocks base method.
+func (m *MockRepository) GetByUsername(ctx context.Context, username string) ([]*domain.PostParser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUsername", ctx, username)
+	ret0, _ := ret[0].([]*domain.PostParser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUsername indicates an expected call of GetByUsername.
+func (mr *MockRepositoryMockRecorder) GetByUsername(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUsername", reflect.TypeOf((*MockRepository)(nil).GetByUsername), ctx, username)
+}
+
+// GetLatestByUsername mocks base method.
+func (m *MockRepository) GetLatestByUsername(ctx context.Context, username string, count int) ([]*domain.PostParser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestByUsername", ctx, username, count)
+	ret0, _ := ret[0].([]*domain.PostParser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestByUsername indicates an expected call of GetLatestByUsername.
+func (mr *MockRepositoryMockRecorder) GetLatestByUsername(ctx, username, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestByUsername", reflect.TypeOf((*MockRepository)(nil).GetLatestByUsername), ctx, username, count)
+}
+
+// GetLatestByUsernameAndSource mocks base method.
+func (m *MockRepository) GetLatestByUsernameAndSource(ctx context.Context, username, source string, count int) ([]*domain.PostParser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestByUsernameAndSource", ctx, username, source, count)
+	ret0, _ := ret[0].([]*domain.PostParser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestByUsernameAndSource indicates an expected call of GetLatestByUsernameAndSource.
+func (mr *MockRepositoryMockRecorder) GetLatestByUsernameAndSource(ctx, username, source, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestByUsernameAndSource", reflect.TypeOf((*MockRepository)(nil).GetLatestByUsernameAndSource), ctx, username, source, count)
+}
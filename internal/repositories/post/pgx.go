@@ -30,16 +30,20 @@ var _ Repository = (*Pgx)(nil)
 
 // Create adds a new post parser entry
 func (p *Pgx) Create(ctx context.Context, post domain.PostParser) error {
+	if post.Source == "" {
+		post.Source = domain.PostSourcePost
+	}
+
 	query, args, err := repositories.SqBuilder.
 		Insert("post_parsers").
-		Columns("post_id", "username", "post_url", "created_at").
-		Values(post.PostID, post.Username, post.PostURL, time.Now()).
+		Columns("post_id", "username", "post_url", "source", "baseline", "created_at").
+		Values(post.PostID, post.Username, post.PostURL, post.Source, post.Baseline, time.Now()).
 		ToSql()
 	if err != nil {
 		return repositories.ErrBadQuery
 	}
 
-	_, err = p.pg.Exec(ctx, query, args...)
+	_, err = repositories.Conn(ctx, p.pg).Exec(ctx, query, args...)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
@@ -53,7 +57,7 @@ func (p *Pgx) Create(ctx context.Context, post domain.PostParser) error {
 // GetByUsername returns all posts for a specific username
 func (p *Pgx) GetByUsername(ctx context.Context, username string) ([]*domain.PostParser, error) {
 	query, args, err := repositories.SqBuilder.
-		Select("id", "post_id", "username", "post_url", "created_at").
+		Select("id", "post_id", "username", "post_url", "source", "baseline", "created_at").
 		From("post_parsers").
 		Where(sq.Eq{"username": username}).
 		OrderBy("created_at DESC").
@@ -71,7 +75,7 @@ func (p *Pgx) GetByUsername(ctx context.Context, username string) ([]*domain.Pos
 	var posts []*domain.PostParser
 	for rows.Next() {
 		var post domain.PostParser
-		if err := rows.Scan(&post.ID, &post.PostID, &post.Username, &post.PostURL, &post.CreatedAt); err != nil {
+		if err := rows.Scan(&post.ID, &post.PostID, &post.Username, &post.PostURL, &post.Source, &post.Baseline, &post.CreatedAt); err != nil {
 			return nil, err
 		}
 		posts = append(posts, &post)
@@ -87,7 +91,7 @@ func (p *Pgx) GetByUsername(ctx context.Context, username string) ([]*domain.Pos
 // GetLatestByUsername returns the most recent posts for a specific username, limited by count
 func (p *Pgx) GetLatestByUsername(ctx context.Context, username string, count int) ([]*domain.PostParser, error) {
 	query, args, err := repositories.SqBuilder.
-		Select("id", "post_id", "username", "post_url", "created_at").
+		Select("id", "post_id", "username", "post_url", "source", "baseline", "created_at").
 		From("post_parsers").
 		Where(sq.Eq{"username": username}).
 		OrderBy("created_at DESC").
@@ -106,7 +110,43 @@ func (p *Pgx) GetLatestByUsername(ctx context.Context, username string, count in
 	var posts []*domain.PostParser
 	for rows.Next() {
 		var post domain.PostParser
-		if err := rows.Scan(&post.ID, &post.PostID, &post.Username, &post.PostURL, &post.CreatedAt); err != nil {
+		if err := rows.Scan(&post.ID, &post.PostID, &post.Username, &post.PostURL, &post.Source, &post.Baseline, &post.CreatedAt); err != nil {
+			return nil, err
+		}
+		posts = append(posts, &post)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
+
+// GetLatestByUsernameAndSource returns the most recent posts of a username recorded by the given
+// checker, limited by count
+func (p *Pgx) GetLatestByUsernameAndSource(ctx context.Context, username, source string, count int) ([]*domain.PostParser, error) {
+	query, args, err := repositories.SqBuilder.
+		Select("id", "post_id", "username", "post_url", "source", "baseline", "created_at").
+		From("post_parsers").
+		Where(sq.Eq{"username": username, "source": source}).
+		OrderBy("created_at DESC").
+		Limit(uint64(count)).
+		ToSql()
+	if err != nil {
+		return nil, repositories.ErrBadQuery
+	}
+
+	rows, err := p.pg.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var posts []*domain.PostParser
+	for rows.Next() {
+		var post domain.PostParser
+		if err := rows.Scan(&post.ID, &post.PostID, &post.Username, &post.PostURL, &post.Source, &post.Baseline, &post.CreatedAt); err != nil {
 			return nil, err
 		}
 		posts = append(posts, &post)
@@ -143,6 +183,28 @@ func (p *Pgx) Exists(ctx context.Context, postID string) (bool, error) {
 	return true, nil
 }
 
+// CountByUsernameSince counts the posts of a username that the post checker found new since
+// the given time. Posts recorded by the first check of the account and reels recorded by the
+// reel checker are left out.
+func (p *Pgx) CountByUsernameSince(ctx context.Context, username string, since time.Time) (int, error) {
+	query, args, err := repositories.SqBuilder.
+		Select("COUNT(*)").
+		From("post_parsers").
+		Where(sq.Eq{"username": username, "source": domain.PostSourcePost, "baseline": false}).
+		Where(sq.GtOrEq{"created_at": since}).
+		ToSql()
+	if err != nil {
+		return 0, repositories.ErrBadQuery
+	}
+
+	var count int
+	if err := p.pg.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
 // CleanupOldRecords deletes records older than the specified duration
 func (p *Pgx) CleanupOldRecords(ctx context.Context, olderThan string) (int64, error) {
 	cutoffTime := time.Now().Add(-parseDuration(olderThan))
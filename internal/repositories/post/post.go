@@ -3,6 +3,7 @@ package post
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
 )
@@ -23,9 +24,17 @@ type Repository interface {
 	// GetLatestByUsername returns the most recent posts for a specific username, limited by count
 	GetLatestByUsername(ctx context.Context, username string, count int) ([]*domain.PostParser, error)
 
+	// GetLatestByUsernameAndSource returns the most recent posts of a username recorded by the given checker
+	GetLatestByUsernameAndSource(ctx context.Context, username, source string, count int) ([]*domain.PostParser, error)
+
 	// Exists checks if a post with the given ID already exists
 	Exists(ctx context.Context, postID string) (bool, error)
 
+	// CountByUsernameSince returns the number of new posts of username the post checker recorded
+	// since the given time. Baseline posts are not counted, since they were not new, and neither
+	// are reels recorded by the reel checker.
+	CountByUsernameSince(ctx context.Context, username string, since time.Time) (int, error)
+
 	// CleanupOldRecords deletes records older than the specified duration
 	CleanupOldRecords(ctx context.Context, olderThan string) (int64, error)
 }
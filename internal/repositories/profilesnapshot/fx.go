@@ -0,0 +1,12 @@
+package profilesnapshot
+
+import (
+	"go.uber.org/fx"
+)
+
+var Module = fx.Provide(
+	fx.Annotate(
+		NewPgxRepository,
+		fx.As(new(Repository)),
+	),
+)
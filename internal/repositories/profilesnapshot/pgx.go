@@ -0,0 +1,97 @@
+package profilesnapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/logger"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+type PgxRepository struct {
+	pool   *pgxpool.Pool
+	logger logger.Logger
+}
+
+func NewPgxRepository(pool *pgxpool.Pool, logger logger.Logger) *PgxRepository {
+	return &PgxRepository{
+		pool:   pool,
+		logger: logger.WithComponent("ProfileSnapshotRepo"),
+	}
+}
+
+var _ Repository = (*PgxRepository)(nil)
+
+func (r *PgxRepository) Create(ctx context.Context, snapshot domain.ProfileSnapshot) (int, error) {
+	query, args, err := repositories.SqBuilder.
+		Insert("profile_snapshots").
+		Columns("username", "full_name", "biography", "avatar_url", "avatar",
+			"follower_count", "following_count", "post_count", "is_verified", "is_private").
+		Values(snapshot.Username, snapshot.FullName, snapshot.Biography, snapshot.AvatarURL, snapshot.Avatar,
+			snapshot.FollowerCount, snapshot.FollowingCount, snapshot.PostCount, snapshot.IsVerified, snapshot.IsPrivate).
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return 0, repositories.ErrBadQuery
+	}
+
+	var id int
+	if err := repositories.Conn(ctx, r.pool).QueryRow(ctx, query, args...).Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to create profile snapshot for %s: %w", snapshot.Username, err)
+	}
+	return id, nil
+}
+
+func (r *PgxRepository) GetLatest(ctx context.Context, username string) (*domain.ProfileSnapshot, error) {
+	query, args, err := repositories.SqBuilder.
+		Select("id", "username", "full_name", "biography", "avatar_url", "avatar",
+			"follower_count", "following_count", "post_count", "is_verified", "is_private", "created_at").
+		From("profile_snapshots").
+		Where(sq.Eq{"username": username}).
+		OrderBy("created_at DESC", "id DESC").
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return nil, repositories.ErrBadQuery
+	}
+
+	var s domain.ProfileSnapshot
+	err = r.pool.QueryRow(ctx, query, args...).Scan(
+		&s.ID, &s.Username, &s.FullName, &s.Biography, &s.AvatarURL, &s.Avatar,
+		&s.FollowerCount, &s.FollowingCount, &s.PostCount, &s.IsVerified, &s.IsPrivate, &s.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get latest profile snapshot for %s: %w", username, err)
+	}
+
+	return &s, nil
+}
+
+func (r *PgxRepository) DeleteOlderThan(ctx context.Context, age time.Duration) (int64, error) {
+	query := `
+		DELETE FROM profile_snapshots s
+		WHERE s.created_at < $1
+		  AND s.id <> (
+			SELECT latest.id FROM profile_snapshots latest
+			WHERE latest.username = s.username
+			ORDER BY latest.created_at DESC, latest.id DESC
+			LIMIT 1
+		  )
+	`
+
+	result, err := r.pool.Exec(ctx, query, time.Now().Add(-age))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete old profile snapshots: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,24 @@
+package profilesnapshot
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+)
+
+var ErrNotFound = errors.New("profile snapshot not found")
+
+//go:generate go run go.uber.org/mock/mockgen -source=profilesnapshot.go -destination=mocks/mock.go
+type Repository interface {
+	// Create stores a new snapshot of a profile and returns its ID
+	Create(ctx context.Context, snapshot domain.ProfileSnapshot) (int, error)
+
+	// GetLatest returns the most recent snapshot of a username
+	GetLatest(ctx context.Context, username string) (*domain.ProfileSnapshot, error)
+
+	// DeleteOlderThan removes snapshots older than the given age, always keeping the latest
+	// snapshot of every username
+	DeleteOlderThan(ctx context.Context, age time.Duration) (int64, error)
+}
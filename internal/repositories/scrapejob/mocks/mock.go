@@ -0,0 +1,173 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: scrapejob.go
+//
+// Generated by this command:
+//
+//	mockgen -source=scrapejob.go -destination=mocks/mock.go
+//
+
+// Package mock_scrapejob is a generated GoMock package.
+package mock_scrapejob
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockRepository) Claim(ctx context.Context) (*domain.ScrapeJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx)
+	ret0, _ := ret[0].(*domain.ScrapeJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockRepositoryMockRecorder) Claim(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockRepository)(nil).Claim), ctx)
+}
+
+// CleanupDeadJobs mocks base method.
+func (m *MockRepository) CleanupDeadJobs(ctx context.Context, olderThan time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanupDeadJobs", ctx, olderThan)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CleanupDeadJobs indicates an expected call of CleanupDeadJobs.
+func (mr *MockRepositoryMockRecorder) CleanupDeadJobs(ctx, olderThan any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanupDeadJobs", reflect.TypeOf((*MockRepository)(nil).CleanupDeadJobs), ctx, olderThan)
+}
+
+// Complete mocks base method.
+func (m *MockRepository) Complete(ctx context.Context, id, claim int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", ctx, id, claim)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockRepositoryMockRecorder) Complete(ctx, id, claim any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockRepository)(nil).Complete), ctx, id, claim)
+}
+
+// Enqueue mocks base method.
+func (m *MockRepository) Enqueue(ctx context.Context, jobType, username string, timeout time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", ctx, jobType, username, timeout)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockRepositoryMockRecorder) Enqueue(ctx, jobType, username, timeout any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockRepository)(nil).Enqueue), ctx, jobType, username, timeout)
+}
+
+// GetDeadJobs mocks base method.
+func (m *MockRepository) GetDeadJobs(ctx context.Context, limit int) ([]*domain.DeadScrapeJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadJobs", ctx, limit)
+	ret0, _ := ret[0].([]*domain.DeadScrapeJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadJobs indicates an expected call of GetDeadJobs.
+func (mr *MockRepositoryMockRecorder) GetDeadJobs(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadJobs", reflect.TypeOf((*MockRepository)(nil).GetDeadJobs), ctx, limit)
+}
+
+// MoveToDeadLetter mocks base method.
+func (m *MockRepository) MoveToDeadLetter(ctx context.Context, id, claim int, lastError string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveToDeadLetter", ctx, id, claim, lastError)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveToDeadLetter indicates an expected call of MoveToDeadLetter.
+func (mr *MockRepositoryMockRecorder) MoveToDeadLetter(ctx, id, claim, lastError any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveToDeadLetter", reflect.TypeOf((*MockRepository)(nil).MoveToDeadLetter), ctx, id, claim, lastError)
+}
+
+// Release mocks base method.
+func (m *MockRepository) Release(ctx context.Context, id, claim int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, id, claim)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockRepositoryMockRecorder) Release(ctx, id, claim any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockRepository)(nil).Release), ctx, id, claim)
+}
+
+// Requeue mocks base method.
+func (m *MockRepository) Requeue(ctx context.Context, deadJobID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Requeue", ctx, deadJobID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Requeue indicates an expected call of Requeue.
+func (mr *MockRepositoryMockRecorder) Requeue(ctx, deadJobID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Requeue", reflect.TypeOf((*MockRepository)(nil).Requeue), ctx, deadJobID)
+}
+
+// Retry mocks base method.
+func (m *MockRepository) Retry(ctx context.Context, id, claim int, lastError string, runAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Retry", ctx, id, claim, lastError, runAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Retry indicates an expected call of Retry.
+func (mr *MockRepositoryMockRecorder) Retry(ctx, id, claim, lastError, runAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retry", reflect.TypeOf((*MockRepository)(nil).Retry), ctx, id, claim, lastError, runAt)
+}
@@ -0,0 +1,265 @@
+package scrapejob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/repositories"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/logger"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+type PgxRepository struct {
+	pool   *pgxpool.Pool
+	logger logger.Logger
+}
+
+func NewPgxRepository(pool *pgxpool.Pool, logger logger.Logger) *PgxRepository {
+	return &PgxRepository{
+		pool:   pool,
+		logger: logger.WithComponent("ScrapeJobRepo"),
+	}
+}
+
+var _ Repository = (*PgxRepository)(nil)
+
+func (r *PgxRepository) Enqueue(ctx context.Context, jobType, username string, timeout time.Duration) (bool, error) {
+	query, args, err := repositories.SqBuilder.
+		Insert("scrape_jobs").
+		Columns("job_type", "username", "timeout_seconds").
+		Values(jobType, username, int(timeout.Seconds())).
+		Suffix("ON CONFLICT (job_type, username) DO NOTHING").
+		ToSql()
+	if err != nil {
+		return false, repositories.ErrBadQuery
+	}
+
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to enqueue %s job for %s: %w", jobType, username, err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+func (r *PgxRepository) Claim(ctx context.Context) (*domain.ScrapeJob, error) {
+	// SKIP LOCKED lets several workers claim different jobs without waiting on each other. A
+	// running job is only due again because its worker died or hung, which counts as a failure.
+	query := `
+		UPDATE scrape_jobs
+		SET attempts = CASE WHEN status = $1 THEN attempts + 1 ELSE attempts END,
+		    last_error = CASE WHEN status = $1 THEN $3 ELSE last_error END,
+		    status = $1,
+		    claims = claims + 1,
+		    locked_until = NOW() + make_interval(secs => timeout_seconds),
+		    updated_at = NOW()
+		WHERE id = (
+			SELECT id FROM scrape_jobs
+			WHERE (status = $2 AND run_at <= NOW())
+			   OR (status = $1 AND locked_until < NOW())
+			ORDER BY run_at, id
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, job_type, username, status, attempts, claims, timeout_seconds, run_at, last_error, created_at, updated_at
+	`
+
+	var job domain.ScrapeJob
+	var timeoutSeconds int
+	err := r.pool.QueryRow(ctx, query, domain.ScrapeJobStatusRunning, domain.ScrapeJobStatusQueued, "visibility timeout expired").Scan(
+		&job.ID, &job.JobType, &job.Username, &job.Status, &job.Attempts, &job.Claim, &timeoutSeconds,
+		&job.RunAt, &job.LastError, &job.CreatedAt, &job.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNoJobAvailable
+		}
+		return nil, fmt.Errorf("failed to claim scrape job: %w", err)
+	}
+	job.Timeout = time.Duration(timeoutSeconds) * time.Second
+
+	return &job, nil
+}
+
+func (r *PgxRepository) Complete(ctx context.Context, id, claim int) error {
+	query, args, err := repositories.SqBuilder.
+		Delete("scrape_jobs").
+		Where(sq.Eq{"id": id, "claims": claim}).
+		ToSql()
+	if err != nil {
+		return repositories.ErrBadQuery
+	}
+
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to complete scrape job %d: %w", id, err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrClaimLost
+	}
+
+	return nil
+}
+
+func (r *PgxRepository) Retry(ctx context.Context, id, claim int, lastError string, runAt time.Time) error {
+	query, args, err := repositories.SqBuilder.
+		Update("scrape_jobs").
+		Set("status", domain.ScrapeJobStatusQueued).
+		Set("attempts", sq.Expr("attempts + 1")).
+		Set("run_at", runAt).
+		Set("locked_until", nil).
+		Set("last_error", lastError).
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"id": id, "claims": claim}).
+		ToSql()
+	if err != nil {
+		return repositories.ErrBadQuery
+	}
+
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to reschedule scrape job %d: %w", id, err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrClaimLost
+	}
+
+	return nil
+}
+
+func (r *PgxRepository) Release(ctx context.Context, id, claim int) error {
+	query, args, err := repositories.SqBuilder.
+		Update("scrape_jobs").
+		Set("status", domain.ScrapeJobStatusQueued).
+		Set("run_at", time.Now()).
+		Set("locked_until", nil).
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"id": id, "claims": claim}).
+		ToSql()
+	if err != nil {
+		return repositories.ErrBadQuery
+	}
+
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to release scrape job %d: %w", id, err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrClaimLost
+	}
+
+	return nil
+}
+
+func (r *PgxRepository) MoveToDeadLetter(ctx context.Context, id, claim int, lastError string) error {
+	query := `
+		WITH job AS (
+			DELETE FROM scrape_jobs WHERE id = $1 AND claims = $2
+			RETURNING job_type, username, attempts + 1 AS attempts, timeout_seconds, created_at
+		)
+		INSERT INTO scrape_dead_jobs (job_type, username, attempts, timeout_seconds, last_error, created_at)
+		SELECT job_type, username, attempts, timeout_seconds, $3, created_at FROM job
+	`
+
+	result, err := r.pool.Exec(ctx, query, id, claim, lastError)
+	if err != nil {
+		return fmt.Errorf("failed to move scrape job %d to dead letters: %w", id, err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrClaimLost
+	}
+
+	return nil
+}
+
+func (r *PgxRepository) GetDeadJobs(ctx context.Context, limit int) ([]*domain.DeadScrapeJob, error) {
+	query, args, err := repositories.SqBuilder.
+		Select("id", "job_type", "username", "attempts", "timeout_seconds", "last_error", "created_at", "failed_at").
+		From("scrape_dead_jobs").
+		OrderBy("failed_at DESC").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, repositories.ErrBadQuery
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dead scrape jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []*domain.DeadScrapeJob
+	for rows.Next() {
+		var job domain.DeadScrapeJob
+		var timeoutSeconds int
+		if err := rows.Scan(&job.ID, &job.JobType, &job.Username, &job.Attempts, &timeoutSeconds, &job.LastError, &job.CreatedAt, &job.FailedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan dead scrape job row: %w", err)
+		}
+		job.Timeout = time.Duration(timeoutSeconds) * time.Second
+		jobs = append(jobs, &job)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating dead scrape job rows: %w", err)
+	}
+
+	return jobs, nil
+}
+
+func (r *PgxRepository) Requeue(ctx context.Context, deadJobID int) error {
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		var jobType, username string
+		var timeoutSeconds int
+		err := tx.QueryRow(ctx,
+			`DELETE FROM scrape_dead_jobs WHERE id = $1 RETURNING job_type, username, timeout_seconds`,
+			deadJobID,
+		).Scan(&jobType, &username, &timeoutSeconds)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrNotFound
+			}
+			return fmt.Errorf("failed to remove dead scrape job %d: %w", deadJobID, err)
+		}
+
+		// A job queued for the same account meanwhile already covers the dead one
+		query, args, err := repositories.SqBuilder.
+			Insert("scrape_jobs").
+			Columns("job_type", "username", "timeout_seconds").
+			Values(jobType, username, timeoutSeconds).
+			Suffix("ON CONFLICT (job_type, username) DO NOTHING").
+			ToSql()
+		if err != nil {
+			return repositories.ErrBadQuery
+		}
+
+		if _, err := tx.Exec(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to requeue dead scrape job %d: %w", deadJobID, err)
+		}
+
+		return nil
+	})
+}
+
+func (r *PgxRepository) CleanupDeadJobs(ctx context.Context, olderThan time.Duration) (int64, error) {
+	query, args, err := repositories.SqBuilder.
+		Delete("scrape_dead_jobs").
+		Where(sq.Lt{"failed_at": time.Now().Add(-olderThan)}).
+		ToSql()
+	if err != nil {
+		return 0, repositories.ErrBadQuery
+	}
+
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete old dead scrape jobs: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,51 @@
+package scrapejob
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+)
+
+var (
+	ErrNoJobAvailable = errors.New("no scrape job available")
+	ErrNotFound       = errors.New("dead scrape job not found")
+	// ErrClaimLost is returned when a job was claimed again after the caller's visibility
+	// timeout expired, so it belongs to another worker now
+	ErrClaimLost = errors.New("scrape job claim lost")
+)
+
+//go:generate go run go.uber.org/mock/mockgen -source=scrapejob.go -destination=mocks/mock.go
+type Repository interface {
+	// Enqueue queues a check of username unless one is already queued or running. It reports
+	// whether a job was added.
+	Enqueue(ctx context.Context, jobType, username string, timeout time.Duration) (bool, error)
+
+	// Claim locks the oldest due job for its visibility timeout under a new claim number. Jobs
+	// whose worker died become due again once the timeout expires, which counts as a failed
+	// attempt. Returns ErrNoJobAvailable when nothing is due.
+	Claim(ctx context.Context) (*domain.ScrapeJob, error)
+
+	// The methods below only act on a job still held under the given claim, and return
+	// ErrClaimLost otherwise.
+
+	// Complete removes a finished job
+	Complete(ctx context.Context, id, claim int) error
+	// Retry counts a failed attempt and releases the job to run again at runAt
+	Retry(ctx context.Context, id, claim int, lastError string, runAt time.Time) error
+	// Release returns an interrupted job to the queue without counting an attempt
+	Release(ctx context.Context, id, claim int) error
+	// MoveToDeadLetter counts a failed attempt, removes the job from the queue and records it
+	// as dead
+	MoveToDeadLetter(ctx context.Context, id, claim int, lastError string) error
+
+	// GetDeadJobs returns up to limit dead jobs, most recently failed first
+	GetDeadJobs(ctx context.Context, limit int) ([]*domain.DeadScrapeJob, error)
+	// Requeue moves a dead job back to the queue with its attempts reset. Returns ErrNotFound
+	// when there is no dead job with that ID.
+	Requeue(ctx context.Context, deadJobID int) error
+
+	// CleanupDeadJobs deletes dead jobs that failed before the given age
+	CleanupDeadJobs(ctx context.Context, olderThan time.Duration) (int64, error)
+}
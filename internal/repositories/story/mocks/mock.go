@@ -12,6 +12,7 @@ package mock_story
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
 	gomock "go.uber.org/mock/gomock"
@@ -21,6 +22,7 @@ import (
 type MockRepository struct {
 	ctrl     *gomock.Controller
 	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
 }
 
 // MockRepositoryMockRecorder is the mock recorder for MockRepository.
@@ -40,6 +42,36 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CleanupOldRecords mocks base method.
+func (m *MockRepository) CleanupOldRecords(ctx context.Context, olderThan time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanupOldRecords", ctx, olderThan)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CleanupOldRecords indicates an expected call of CleanupOldRecords.
+func (mr *MockRepositoryMockRecorder) CleanupOldRecords(ctx, olderThan any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanupOldRecords", reflect.TypeOf((*MockRepository)(nil).CleanupOldRecords), ctx, olderThan)
+}
+
+// CountByUsernameSince mocks base method.
+func (m *MockRepository) CountByUsernameSince(ctx context.Context, username string, since time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByUsernameSince", ctx, username, since)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByUsernameSince indicates an expected call of CountByUsernameSince.
+func (mr *MockRepositoryMockRecorder) CountByUsernameSince(ctx, username, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByUsernameSince", reflect.TypeOf((*MockRepository)(nil).CountByUsernameSince), ctx, username, since)
+}
+
 // Create mocks base method.
 func (m *MockRepository) Create(ctx context.Context, user domain.Story) error {
 	m.ctrl.T.Helper()
@@ -96,7 +96,7 @@ func (p *Pgx) Create(ctx context.Context, story domain.Story) error {
 		return repositories.ErrBadQuery
 	}
 
-	_, err = p.pg.Exec(ctx, query, args...)
+	_, err = repositories.Conn(ctx, p.pg).Exec(ctx, query, args...)
 	if err != nil {
 		return errors.Join(err, ErrCannotCreate)
 	}
@@ -122,3 +122,22 @@ func (p *Pgx) CleanupOldRecords(ctx context.Context, olderThan time.Duration) (i
 
 	return result.RowsAffected(), nil
 }
+
+func (p *Pgx) CountByUsernameSince(ctx context.Context, username string, since time.Time) (int, error) {
+	query, args, err := repositories.SqBuilder.
+		Select("COUNT(*)").
+		From("story_parsers").
+		Where(sq.Eq{"username": username}).
+		Where(sq.GtOrEq{"created_at": since}).
+		ToSql()
+	if err != nil {
+		return 0, repositories.ErrBadQuery
+	}
+
+	var count int
+	if err := p.pg.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -25,4 +25,6 @@ type Repository interface {
 	GetByStoryID(ctx context.Context, storyID string) (*domain.Story, error)
 	Create(ctx context.Context, user domain.Story) error
 	CleanupOldRecords(ctx context.Context, olderThan time.Duration) (int64, error)
+	// CountByUsernameSince returns the number of stories of username taken since the given time
+	CountByUsernameSince(ctx context.Context, username string, since time.Time) (int, error)
 }
@@ -0,0 +1,129 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: subscription.go
+//
+// Generated by this command:
+//
+//	mockgen -source=subscription.go -destination=mocks/mock.go
+//
+
+// Package mock_subscription is a generated GoMock package.
+package mock_subscription
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRepository) Create(ctx context.Context, sub domain.Subscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, sub)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder) Create(ctx, sub any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, sub)
+}
+
+// Delete mocks base method.
+func (m *MockRepository) Delete(ctx context.Context, chatID int64, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, chatID, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder) Delete(ctx, chatID, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, chatID, username)
+}
+
+// GetAllUniqueUsernamesByType mocks base method.
+func (m *MockRepository) GetAllUniqueUsernamesByType(ctx context.Context, subscriptionType string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllUniqueUsernamesByType", ctx, subscriptionType)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllUniqueUsernamesByType indicates an expected call of GetAllUniqueUsernamesByType.
+func (mr *MockRepositoryMockRecorder) GetAllUniqueUsernamesByType(ctx, subscriptionType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUniqueUsernamesByType", reflect.TypeOf((*MockRepository)(nil).GetAllUniqueUsernamesByType), ctx, subscriptionType)
+}
+
+// GetByChatID mocks base method.
+func (m *MockRepository) GetByChatID(ctx context.Context, chatID int64) ([]*domain.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByChatID", ctx, chatID)
+	ret0, _ := ret[0].([]*domain.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByChatID indicates an expected call of GetByChatID.
+func (mr *MockRepositoryMockRecorder) GetByChatID(ctx, chatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByChatID", reflect.TypeOf((*MockRepository)(nil).GetByChatID), ctx, chatID)
+}
+
+// GetSubscribersForUserByType mocks base method.
+func (m *MockRepository) GetSubscribersForUserByType(ctx context.Context, username, subscriptionType string) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscribersForUserByType", ctx, username, subscriptionType)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscribersForUserByType indicates an expected call of GetSubscribersForUserByType.
+func (mr *MockRepositoryMockRecorder) GetSubscribersForUserByType(ctx, username, subscriptionType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscribersForUserByType", reflect.TypeOf((*MockRepository)(nil).GetSubscribersForUserByType), ctx, username, subscriptionType)
+}
+
+// UpdateSubscriptionType mocks base method.
+func (m *MockRepository) UpdateSubscriptionType(ctx context.Context, chatID int64, username, subscriptionType string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubscriptionType", ctx, chatID, username, subscriptionType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSubscriptionType indicates an expected call of UpdateSubscriptionType.
+func (mr *MockRepositoryMockRecorder) UpdateSubscriptionType(ctx, chatID, username, subscriptionType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscriptionType", reflect.TypeOf((*MockRepository)(nil).UpdateSubscriptionType), ctx, chatID, username, subscriptionType)
+}
@@ -108,85 +108,13 @@ func (r *PgxRepository) GetByChatID(ctx context.Context, chatID int64) ([]*domai
 	return subs, nil
 }
 
-func (r *PgxRepository) GetAllUniqueUsernames(ctx context.Context) ([]string, error) {
-	query := `SELECT DISTINCT instagram_username FROM subscriptions`
-
-	rows, err := r.pool.Query(ctx, query)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var usernames []string
-	for rows.Next() {
-		var username string
-		if err := rows.Scan(&username); err != nil {
-			return nil, err
-		}
-		usernames = append(usernames, username)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-
-	return usernames, nil
-}
-
-func (r *PgxRepository) GetSubscribersForUser(ctx context.Context, username string) ([]int64, error) {
-	query, args, err := repositories.SqBuilder.
+// GetSubscribersForUserByType returns chat IDs of users subscribed to a specific username with a specific subscription type
+func (r *PgxRepository) GetSubscribersForUserByType(ctx context.Context, username string, subscriptionType string) ([]int64, error) {
+	builder := repositories.SqBuilder.
 		Select("chat_id").
 		From("subscriptions").
 		Where(sq.Eq{"instagram_username": username}).
-		ToSql()
-	if err != nil {
-		return nil, repositories.ErrBadQuery
-	}
-
-	rows, err := r.pool.Query(ctx, query, args...)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var chatIDs []int64
-	for rows.Next() {
-		var chatID int64
-		if err := rows.Scan(&chatID); err != nil {
-			return nil, err
-		}
-		chatIDs = append(chatIDs, chatID)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-
-	return chatIDs, nil
-}
-
-// GetSubscribersForUserByType returns chat IDs of users subscribed to a specific username with a specific subscription type
-func (r *PgxRepository) GetSubscribersForUserByType(ctx context.Context, username string, subscriptionType string) ([]int64, error) {
-	var builder sq.SelectBuilder
-	if subscriptionType == domain.SubscriptionTypeAll {
-		builder = repositories.SqBuilder.
-			Select("chat_id").
-			From("subscriptions").
-			Where(sq.Eq{"instagram_username": username}).
-			Where(sq.Or{
-				sq.Eq{"subscription_type": domain.SubscriptionTypeAll},
-				sq.Eq{"subscription_type": subscriptionType},
-			})
-	} else {
-		builder = repositories.SqBuilder.
-			Select("chat_id").
-			From("subscriptions").
-			Where(sq.Eq{"instagram_username": username}).
-			Where(sq.Or{
-				sq.Eq{"subscription_type": domain.SubscriptionTypeAll},
-				sq.Eq{"subscription_type": subscriptionType},
-			})
-	}
+		Where(sq.Eq{"subscription_type": domain.SubscriptionTypesFor(subscriptionType)})
 
 	query, args, err := builder.ToSql()
 	if err != nil {
@@ -217,25 +145,13 @@ func (r *PgxRepository) GetSubscribersForUserByType(ctx context.Context, usernam
 
 // GetAllUniqueUsernamesByType returns all unique usernames with a specific subscription type
 func (r *PgxRepository) GetAllUniqueUsernamesByType(ctx context.Context, subscriptionType string) ([]string, error) {
-	var query string
-	var args []interface{}
-	var err error
-
-	if subscriptionType == "" {
-		query = `SELECT DISTINCT instagram_username FROM subscriptions`
-	} else {
-		builder := repositories.SqBuilder.
-			Select("DISTINCT instagram_username").
-			From("subscriptions").
-			Where(sq.Or{
-				sq.Eq{"subscription_type": domain.SubscriptionTypeAll},
-				sq.Eq{"subscription_type": subscriptionType},
-			})
-
-		query, args, err = builder.ToSql()
-		if err != nil {
-			return nil, repositories.ErrBadQuery
-		}
+	query, args, err := repositories.SqBuilder.
+		Select("DISTINCT instagram_username").
+		From("subscriptions").
+		Where(sq.Eq{"subscription_type": domain.SubscriptionTypesFor(subscriptionType)}).
+		ToSql()
+	if err != nil {
+		return nil, repositories.ErrBadQuery
 	}
 
 	rows, err := r.pool.Query(ctx, query, args...)
@@ -17,11 +17,12 @@ type Repository interface {
 	Create(ctx context.Context, sub domain.Subscription) error
 	Delete(ctx context.Context, chatID int64, username string) error
 	GetByChatID(ctx context.Context, chatID int64) ([]*domain.Subscription, error)
-	GetAllUniqueUsernames(ctx context.Context) ([]string, error)
-	GetSubscribersForUser(ctx context.Context, username string) ([]int64, error)
 
-	// New methods for subscription types
+	// GetSubscribersForUserByType returns the chats that receive subscriptionType content of
+	// username: those subscribed with a type of domain.SubscriptionTypesFor(subscriptionType)
 	GetSubscribersForUserByType(ctx context.Context, username string, subscriptionType string) ([]int64, error)
+	// GetAllUniqueUsernamesByType returns the usernames with at least one subscription that
+	// receives subscriptionType content
 	GetAllUniqueUsernamesByType(ctx context.Context, subscriptionType string) ([]string, error)
 	UpdateSubscriptionType(ctx context.Context, chatID int64, username string, subscriptionType string) error
 }
@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DBTX is the part of the pgx API shared by the pool and a transaction
+type DBTX interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// Transactor runs a function inside a database transaction. Repositories that get their
+// connection through Conn join the transaction carried by the context.
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type txKey struct{}
+
+type PgxTransactor struct {
+	pool *pgxpool.Pool
+}
+
+func NewPgxTransactor(pool *pgxpool.Pool) *PgxTransactor {
+	return &PgxTransactor{pool: pool}
+}
+
+var _ Transactor = (*PgxTransactor)(nil)
+
+// WithinTx commits when fn returns nil and rolls back otherwise. Nested calls reuse the
+// outer transaction.
+func (t *PgxTransactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := t.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		_ = tx.Rollback(ctx)
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Conn returns the transaction carried by ctx, or pool when there is none
+func Conn(ctx context.Context, pool *pgxpool.Pool) DBTX {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return pool
+}
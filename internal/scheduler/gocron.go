@@ -0,0 +1,243 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/config"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/logger"
+	"go.uber.org/fx"
+)
+
+// entry is the state of a registered job. It outlives the gocron job, so the history survives
+// rescheduling, e.g. when the replica becomes leader again.
+type entry struct {
+	job       Job
+	ctx       context.Context
+	gocronJob gocron.Job // Nil while the job is not scheduled
+
+	paused       bool
+	running      bool
+	lastRun      time.Time
+	lastDuration time.Duration
+	lastError    string
+}
+
+type GocronScheduler struct {
+	scheduler gocron.Scheduler
+	logger    logger.Logger
+
+	mu      sync.Mutex
+	entries map[string]*entry
+}
+
+func NewGocronScheduler(lc fx.Lifecycle, cfg *config.Config, logger logger.Logger) (*GocronScheduler, error) {
+	logger = logger.WithComponent("Scheduler")
+
+	loc, err := time.LoadLocation(cfg.App.Timezone)
+	if err != nil {
+		loc = time.Local
+		logger.Warn("Failed to load timezone, using local timezone", "timezone", cfg.App.Timezone, "error", err)
+	}
+
+	s, err := gocron.NewScheduler(gocron.WithLocation(loc))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scheduler: %w", err)
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			s.Start()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return s.Shutdown()
+		},
+	})
+
+	return &GocronScheduler{
+		scheduler: s,
+		logger:    logger,
+		entries:   make(map[string]*entry),
+	}, nil
+}
+
+var _ Scheduler = (*GocronScheduler)(nil)
+
+func (s *GocronScheduler) Schedule(ctx context.Context, job Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[job.Name]
+	if !ok {
+		e = &entry{}
+		s.entries[job.Name] = e
+	}
+	if e.gocronJob != nil {
+		if err := s.scheduler.RemoveJob(e.gocronJob.ID()); err != nil {
+			s.logger.Warn("Failed to remove previous registration of job", "job", job.Name, "error", err)
+		}
+		e.gocronJob = nil
+	}
+
+	options := append([]gocron.JobOption{gocron.WithName(job.Name), gocron.WithContext(ctx)}, job.Options...)
+	gocronJob, err := s.scheduler.NewJob(
+		job.Definition,
+		gocron.NewTask(func() {
+			_ = s.execute(ctx, job.Name, false)
+		}),
+		options...,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule job %s: %w", job.Name, err)
+	}
+
+	e.job = job
+	e.ctx = ctx
+	e.gocronJob = gocronJob
+	s.logger.Info("Scheduled job", "job", job.Name)
+
+	go func() {
+		<-ctx.Done()
+		s.unschedule(job.Name, gocronJob)
+	}()
+
+	return nil
+}
+
+// unschedule removes the gocron job of a registration whose context is done, unless the job
+// was scheduled again meanwhile.
+func (s *GocronScheduler) unschedule(name string, gocronJob gocron.Job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := s.entries[name]
+	if e == nil || e.gocronJob == nil || e.gocronJob.ID() != gocronJob.ID() {
+		return
+	}
+
+	if err := s.scheduler.RemoveJob(gocronJob.ID()); err != nil {
+		s.logger.Warn("Failed to remove job", "job", name, "error", err)
+	}
+	e.gocronJob = nil
+	s.logger.Info("Unscheduled job", "job", name)
+}
+
+// execute runs the job's task and records the outcome. Scheduled runs of a paused job are
+// skipped, manual ones are not.
+func (s *GocronScheduler) execute(ctx context.Context, name string, manual bool) error {
+	s.mu.Lock()
+	e := s.entries[name]
+	if e == nil {
+		s.mu.Unlock()
+		return ErrJobNotFound
+	}
+	if e.running {
+		s.mu.Unlock()
+		s.logger.Warn("Skipping job run, the previous one is still going", "job", name)
+		return ErrJobRunning
+	}
+	if e.paused && !manual {
+		s.mu.Unlock()
+		s.logger.Info("Skipping run of paused job", "job", name)
+		return nil
+	}
+	e.running = true
+	task := e.job.Task
+	s.mu.Unlock()
+
+	start := time.Now()
+	err := task(ctx)
+	duration := time.Since(start)
+
+	s.mu.Lock()
+	e.running = false
+	e.lastRun = start
+	e.lastDuration = duration
+	e.lastError = ""
+	if err != nil {
+		e.lastError = err.Error()
+	}
+	s.mu.Unlock()
+
+	if err != nil {
+		s.logger.Error("Job failed", "job", name, "duration", duration, "error", err)
+	} else {
+		s.logger.Info("Job finished", "job", name, "duration", duration)
+	}
+
+	return err
+}
+
+func (s *GocronScheduler) Jobs() []JobStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statuses := make([]JobStatus, 0, len(s.entries))
+	for name, e := range s.entries {
+		status := JobStatus{
+			Name:         name,
+			Scheduled:    e.gocronJob != nil,
+			Paused:       e.paused,
+			Running:      e.running,
+			LastRun:      e.lastRun,
+			LastDuration: e.lastDuration,
+			LastError:    e.lastError,
+		}
+		if e.gocronJob != nil {
+			if next, err := e.gocronJob.NextRun(); err == nil {
+				status.NextRun = next
+			}
+		}
+		statuses = append(statuses, status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+
+	return statuses
+}
+
+func (s *GocronScheduler) RunNow(name string) error {
+	s.mu.Lock()
+	e := s.entries[name]
+	if e == nil {
+		s.mu.Unlock()
+		return ErrJobNotFound
+	}
+	if e.gocronJob == nil {
+		s.mu.Unlock()
+		return ErrJobNotScheduled
+	}
+	if e.running {
+		s.mu.Unlock()
+		return ErrJobRunning
+	}
+	ctx := e.ctx
+	s.mu.Unlock()
+
+	go func() {
+		_ = s.execute(ctx, name, true)
+	}()
+
+	return nil
+}
+
+func (s *GocronScheduler) SetPaused(name string, paused bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := s.entries[name]
+	if e == nil {
+		return ErrJobNotFound
+	}
+
+	e.paused = paused
+	s.logger.Info("Changed job paused state", "job", name, "paused", paused)
+	return nil
+}
@@ -0,0 +1,50 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+)
+
+var (
+	ErrJobNotFound     = errors.New("job not found")
+	ErrJobNotScheduled = errors.New("job is not scheduled on this replica")
+	ErrJobRunning      = errors.New("job is already running")
+)
+
+// Job is a periodic task owned by the scheduler
+type Job struct {
+	Name       string
+	Definition gocron.JobDefinition
+	Options    []gocron.JobOption // Extra gocron options, e.g. gocron.WithStartAt
+	Task       func(ctx context.Context) error
+}
+
+// JobStatus describes a registered job for the admin commands
+type JobStatus struct {
+	Name         string
+	Scheduled    bool // False once the context the job was scheduled with is done
+	Paused       bool
+	Running      bool
+	LastRun      time.Time
+	NextRun      time.Time
+	LastDuration time.Duration
+	LastError    string
+}
+
+// Scheduler owns every periodic job of the bot
+type Scheduler interface {
+	// Schedule runs job on its definition until ctx is done. Scheduling a job under a name that
+	// is already known replaces the previous registration but keeps its history and paused
+	// state. A run that becomes due while the previous one is still going is skipped.
+	Schedule(ctx context.Context, job Job) error
+
+	// Jobs returns the status of every registered job, ordered by name
+	Jobs() []JobStatus
+	// RunNow starts a run of the job in the background, even when it is paused
+	RunNow(name string) error
+	// SetPaused pauses or resumes the scheduled runs of the job
+	SetPaused(name string, paused bool) error
+}
@@ -0,0 +1,267 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: telegram.go
+//
+// Generated by this command:
+//
+//	mockgen -source=telegram.go -destination=mocks/mock.go
+//
+
+// Package mock_telegram is a generated GoMock package.
+package mock_telegram
+
+import (
+	reflect "reflect"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	domain "github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockClient is a mock of Client interface.
+type MockClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientMockRecorder
+	isgomock struct{}
+}
+
+// MockClientMockRecorder is the mock recorder for MockClient.
+type MockClientMockRecorder struct {
+	mock *MockClient
+}
+
+// NewMockClient creates a new mock instance.
+func NewMockClient(ctrl *gomock.Controller) *MockClient {
+	mock := &MockClient{ctrl: ctrl}
+	mock.recorder = &MockClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClient) EXPECT() *MockClientMockRecorder {
+	return m.recorder
+}
+
+// DeleteMessage mocks base method.
+func (m *MockClient) DeleteMessage(config tgbotapi.DeleteMessageConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMessage", config)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMessage indicates an expected call of DeleteMessage.
+func (mr *MockClientMockRecorder) DeleteMessage(config any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMessage", reflect.TypeOf((*MockClient)(nil).DeleteMessage), config)
+}
+
+// DownloadMedia mocks base method.
+func (m *MockClient) DownloadMedia(url string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadMedia", url)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadMedia indicates an expected call of DownloadMedia.
+func (mr *MockClientMockRecorder) DownloadMedia(url any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadMedia", reflect.TypeOf((*MockClient)(nil).DownloadMedia), url)
+}
+
+// DownloadMediaToTempFile mocks base method.
+func (m *MockClient) DownloadMediaToTempFile(url string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadMediaToTempFile", url)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadMediaToTempFile indicates an expected call of DownloadMediaToTempFile.
+func (mr *MockClientMockRecorder) DownloadMediaToTempFile(url any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadMediaToTempFile", reflect.TypeOf((*MockClient)(nil).DownloadMediaToTempFile), url)
+}
+
+// EditMessageText mocks base method.
+func (m *MockClient) EditMessageText(chatID int64, messageID int, newText string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditMessageText", chatID, messageID, newText)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EditMessageText indicates an expected call of EditMessageText.
+func (mr *MockClientMockRecorder) EditMessageText(chatID, messageID, newText any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditMessageText", reflect.TypeOf((*MockClient)(nil).EditMessageText), chatID, messageID, newText)
+}
+
+// GetUpdatesChan mocks base method.
+func (m *MockClient) GetUpdatesChan(u tgbotapi.UpdateConfig) tgbotapi.UpdatesChannel {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpdatesChan", u)
+	ret0, _ := ret[0].(tgbotapi.UpdatesChannel)
+	return ret0
+}
+
+// GetUpdatesChan indicates an expected call of GetUpdatesChan.
+func (mr *MockClientMockRecorder) GetUpdatesChan(u any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpdatesChan", reflect.TypeOf((*MockClient)(nil).GetUpdatesChan), u)
+}
+
+// Request mocks base method.
+func (m *MockClient) Request(c tgbotapi.Chattable) (*tgbotapi.APIResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Request", c)
+	ret0, _ := ret[0].(*tgbotapi.APIResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Request indicates an expected call of Request.
+func (mr *MockClientMockRecorder) Request(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Request", reflect.TypeOf((*MockClient)(nil).Request), c)
+}
+
+// Send mocks base method.
+func (m *MockClient) Send(c tgbotapi.Chattable) (tgbotapi.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", c)
+	ret0, _ := ret[0].(tgbotapi.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockClientMockRecorder) Send(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockClient)(nil).Send), c)
+}
+
+// SendMedia mocks base method.
+func (m *MockClient) SendMedia(chatID int64, item domain.MediaItem, caption string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMedia", chatID, item, caption)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendMedia indicates an expected call of SendMedia.
+func (mr *MockClientMockRecorder) SendMedia(chatID, item, caption any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMedia", reflect.TypeOf((*MockClient)(nil).SendMedia), chatID, item, caption)
+}
+
+// SendMediaAlbum mocks base method.
+func (m *MockClient) SendMediaAlbum(chatID int64, items []domain.MediaItem, caption string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMediaAlbum", chatID, items, caption)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMediaAlbum indicates an expected call of SendMediaAlbum.
+func (mr *MockClientMockRecorder) SendMediaAlbum(chatID, items, caption any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMediaAlbum", reflect.TypeOf((*MockClient)(nil).SendMediaAlbum), chatID, items, caption)
+}
+
+// SendMediaByUrl mocks base method.
+func (m *MockClient) SendMediaByUrl(chatID int64, url string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMediaByUrl", chatID, url)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMediaByUrl indicates an expected call of SendMediaByUrl.
+func (mr *MockClientMockRecorder) SendMediaByUrl(chatID, url any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMediaByUrl", reflect.TypeOf((*MockClient)(nil).SendMediaByUrl), chatID, url)
+}
+
+// SendMediaGroup mocks base method.
+func (m *MockClient) SendMediaGroup(chatID int64, media []any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMediaGroup", chatID, media)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMediaGroup indicates an expected call of SendMediaGroup.
+func (mr *MockClientMockRecorder) SendMediaGroup(chatID, media any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMediaGroup", reflect.TypeOf((*MockClient)(nil).SendMediaGroup), chatID, media)
+}
+
+// SendMediaToDefaultChannelByUrl mocks base method.
+func (m *MockClient) SendMediaToDefaultChannelByUrl(url string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SendMediaToDefaultChannelByUrl", url)
+}
+
+// SendMediaToDefaultChannelByUrl indicates an expected call of SendMediaToDefaultChannelByUrl.
+func (mr *MockClientMockRecorder) SendMediaToDefaultChannelByUrl(url any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMediaToDefaultChannelByUrl", reflect.TypeOf((*MockClient)(nil).SendMediaToDefaultChannelByUrl), url)
+}
+
+// SendMessage mocks base method.
+func (m *MockClient) SendMessage(chatID int64, text string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMessage", chatID, text)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendMessage indicates an expected call of SendMessage.
+func (mr *MockClientMockRecorder) SendMessage(chatID, text any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessage", reflect.TypeOf((*MockClient)(nil).SendMessage), chatID, text)
+}
+
+// SendMessageToDefaultChannel mocks base method.
+func (m *MockClient) SendMessageToDefaultChannel(msg string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SendMessageToDefaultChannel", msg)
+}
+
+// SendMessageToDefaultChannel indicates an expected call of SendMessageToDefaultChannel.
+func (mr *MockClientMockRecorder) SendMessageToDefaultChannel(msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessageToDefaultChannel", reflect.TypeOf((*MockClient)(nil).SendMessageToDefaultChannel), msg)
+}
+
+// SendMessageWithParseMode mocks base method.
+func (m *MockClient) SendMessageWithParseMode(chatID int64, text, parseMode string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMessageWithParseMode", chatID, text, parseMode)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendMessageWithParseMode indicates an expected call of SendMessageWithParseMode.
+func (mr *MockClientMockRecorder) SendMessageWithParseMode(chatID, text, parseMode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessageWithParseMode", reflect.TypeOf((*MockClient)(nil).SendMessageWithParseMode), chatID, text, parseMode)
+}
+
+// StopReceivingUpdates mocks base method.
+func (m *MockClient) StopReceivingUpdates() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "StopReceivingUpdates")
+}
+
+// StopReceivingUpdates indicates an expected call of StopReceivingUpdates.
+func (mr *MockClientMockRecorder) StopReceivingUpdates() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopReceivingUpdates", reflect.TypeOf((*MockClient)(nil).StopReceivingUpdates))
+}
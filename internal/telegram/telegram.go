@@ -2,8 +2,10 @@ package telegram
 
 import (
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
 )
 
+//go:generate go run go.uber.org/mock/mockgen -source=telegram.go -destination=mocks/mock.go
 type Client interface {
 	GetUpdatesChan(u tgbotapi.UpdateConfig) tgbotapi.UpdatesChannel
 	StopReceivingUpdates()
@@ -11,6 +13,8 @@ type Client interface {
 	SendMessage(chatID int64, text string) (int, error)
 	SendMessageWithParseMode(chatID int64, text string, parseMode string) (int, error)
 	SendMediaByUrl(chatID int64, url string) error
+	SendMedia(chatID int64, item domain.MediaItem, caption string) (int, error)
+	SendMediaAlbum(chatID int64, items []domain.MediaItem, caption string) error
 	SendMediaGroup(chatID int64, media []interface{}) error
 	EditMessageText(chatID int64, messageID int, newText string) error
 	DeleteMessage(config tgbotapi.DeleteMessageConfig) error
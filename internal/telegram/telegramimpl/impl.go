@@ -2,6 +2,7 @@ package telegramimpl
 
 import (
 	"fmt"
+	"sync"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/orgball2608/insta-parser-telegram-bot/internal/telegram"
@@ -21,6 +22,9 @@ type TelegramImpl struct {
 	TgBot  *tgbotapi.BotAPI
 	Logger logger.Logger
 	Config *config.Config
+
+	updatesMu   sync.Mutex
+	stopUpdates chan struct{} // Closed to stop the running update poller
 }
 
 func New(opts Opts) (*TelegramImpl, error) {
@@ -0,0 +1,192 @@
+package telegramimpl
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/orgball2608/insta-parser-telegram-bot/internal/domain"
+)
+
+const (
+	// maxUploadBytes is the largest file the Bot API accepts for upload.
+	maxUploadBytes = 50 << 20
+	// maxMediaGroupSize is the largest number of items in a Telegram album.
+	maxMediaGroupSize = 10
+)
+
+// SendMedia downloads the best rendition of item that Telegram accepts and sends it as a photo
+// or video with the given plain-text caption. It returns the ID of the sent message.
+func (tg *TelegramImpl) SendMedia(chatID int64, item domain.MediaItem, caption string) (int, error) {
+	data, err := tg.downloadBestRendition(item)
+	if err != nil {
+		return 0, err
+	}
+	file := tgbotapi.FileBytes{Name: "media", Bytes: data}
+
+	var msg tgbotapi.Chattable
+	if item.IsVideo() {
+		video := tgbotapi.NewVideo(chatID, file)
+		video.Caption = caption
+		video.Duration = int(item.Duration.Seconds())
+		video.SupportsStreaming = true
+		if thumb := tg.downloadThumbnail(item); thumb != nil {
+			video.Thumb = tgbotapi.FileBytes{Name: "thumb.jpg", Bytes: thumb}
+		}
+		msg = video
+	} else {
+		photo := tgbotapi.NewPhoto(chatID, file)
+		photo.Caption = caption
+		msg = photo
+	}
+
+	sent, err := tg.TgBot.Send(msg)
+	if err != nil {
+		tg.Logger.Error("Error sending media", "chatID", chatID, "type", item.Type, "error", err)
+		return 0, fmt.Errorf("failed to send media: %w", err)
+	}
+	return sent.MessageID, nil
+}
+
+// SendMediaAlbum sends items as Telegram albums of at most ten, keeping their order and putting
+// caption on the first item. Photos and videos can be mixed freely. Each chunk is downloaded
+// concurrently to temp files so large videos are not held in memory.
+func (tg *TelegramImpl) SendMediaAlbum(chatID int64, items []domain.MediaItem, caption string) error {
+	if len(items) == 1 {
+		_, err := tg.SendMedia(chatID, items[0], caption)
+		return err
+	}
+
+	for start := 0; start < len(items); start += maxMediaGroupSize {
+		chunk := items[start:min(start+maxMediaGroupSize, len(items))]
+		chunkCaption := ""
+		if start == 0 {
+			chunkCaption = caption
+		}
+		if err := tg.sendAlbumChunk(chatID, chunk, chunkCaption); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (tg *TelegramImpl) sendAlbumChunk(chatID int64, items []domain.MediaItem, caption string) error {
+	paths := make([]string, len(items))
+	var wg sync.WaitGroup
+	for i, item := range items {
+		wg.Add(1)
+		go func(i int, item domain.MediaItem) {
+			defer wg.Done()
+			data, err := tg.downloadBestRendition(item)
+			if err != nil {
+				tg.Logger.Error("Skipping album item that could not be downloaded", "url", item.URL, "error", err)
+				return
+			}
+			path, err := writeTempFile(data)
+			if err != nil {
+				tg.Logger.Error("Skipping album item that could not be stored", "url", item.URL, "error", err)
+				return
+			}
+			paths[i] = path
+		}(i, item)
+	}
+	wg.Wait()
+
+	defer func() {
+		for _, path := range paths {
+			if path == "" {
+				continue
+			}
+			if err := os.Remove(path); err != nil {
+				tg.Logger.Warn("Failed to remove temp file", "path", path, "error", err)
+			}
+		}
+	}()
+
+	group := make([]interface{}, 0, len(items))
+	for i, item := range items {
+		if paths[i] == "" {
+			continue
+		}
+		file := tgbotapi.FilePath(paths[i])
+
+		itemCaption := ""
+		if len(group) == 0 {
+			itemCaption = caption
+		}
+
+		if item.IsVideo() {
+			video := tgbotapi.NewInputMediaVideo(file)
+			video.Caption = itemCaption
+			video.Width = item.Width
+			video.Height = item.Height
+			video.Duration = int(item.Duration.Seconds())
+			video.SupportsStreaming = true
+			group = append(group, video)
+		} else {
+			photo := tgbotapi.NewInputMediaPhoto(file)
+			photo.Caption = itemCaption
+			group = append(group, photo)
+		}
+	}
+
+	switch len(group) {
+	case 0:
+		return fmt.Errorf("failed to download any of %d album items", len(items))
+	case 1:
+		// Telegram rejects albums with a single item
+		for i, item := range items {
+			if paths[i] != "" {
+				_, err := tg.SendMedia(chatID, item, caption)
+				return err
+			}
+		}
+	}
+	return tg.SendMediaGroup(chatID, group)
+}
+
+// downloadBestRendition tries the renditions of item from best to worst and returns the first
+// one small enough to upload.
+func (tg *TelegramImpl) downloadBestRendition(item domain.MediaItem) ([]byte, error) {
+	urls := make([]string, 0, len(item.Resolutions)+1)
+	for _, r := range item.Resolutions {
+		urls = append(urls, r.URL)
+	}
+	if len(urls) == 0 && item.URL != "" {
+		urls = append(urls, item.URL)
+	}
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("media item has no URL")
+	}
+
+	var lastErr error
+	for _, url := range urls {
+		data, err := tg.downloadWithRetry(url)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if len(data) > maxUploadBytes {
+			lastErr = fmt.Errorf("media at %s is %d bytes, over the upload limit", url, len(data))
+			tg.Logger.Warn("Rendition too large for Telegram, trying a smaller one", "url", url, "size", len(data))
+			continue
+		}
+		return data, nil
+	}
+	return nil, lastErr
+}
+
+// downloadThumbnail fetches a video's thumbnail, returning nil when there is none or it fails.
+func (tg *TelegramImpl) downloadThumbnail(item domain.MediaItem) []byte {
+	if item.ThumbnailURL == "" {
+		return nil
+	}
+	data, err := tg.downloadMedia(item.ThumbnailURL)
+	if err != nil {
+		tg.Logger.Warn("Could not download video thumbnail", "url", item.ThumbnailURL, "error", err)
+		return nil
+	}
+	return data
+}
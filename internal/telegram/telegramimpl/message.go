@@ -10,16 +10,71 @@ import (
 	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	apperrors "github.com/orgball2608/insta-parser-telegram-bot/pkg/errors"
 	"github.com/orgball2608/insta-parser-telegram-bot/pkg/logger"
 	"github.com/orgball2608/insta-parser-telegram-bot/pkg/retry"
 )
 
+// GetUpdatesChan long-polls for updates until StopReceivingUpdates is called. Unlike the
+// library's poller it can be started again after being stopped, which a replica needs when it
+// becomes leader a second time.
 func (tg *TelegramImpl) GetUpdatesChan(u tgbotapi.UpdateConfig) tgbotapi.UpdatesChannel {
-	return tg.TgBot.GetUpdatesChan(u)
+	stop := make(chan struct{})
+	tg.updatesMu.Lock()
+	if tg.stopUpdates != nil {
+		close(tg.stopUpdates)
+	}
+	tg.stopUpdates = stop
+	tg.updatesMu.Unlock()
+
+	ch := make(chan tgbotapi.Update, tg.TgBot.Buffer)
+
+	go func() {
+		defer close(ch)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+
+			updates, err := tg.TgBot.GetUpdates(u)
+			if err != nil {
+				tg.Logger.Warn("Failed to get updates, retrying in 3 seconds", "error", err)
+				select {
+				case <-stop:
+					return
+				case <-time.After(3 * time.Second):
+				}
+				continue
+			}
+
+			for _, update := range updates {
+				if update.UpdateID < u.Offset {
+					continue
+				}
+				// Updates fetched after stopping are not acknowledged, so the next poller gets them again
+				select {
+				case <-stop:
+					return
+				case ch <- update:
+					u.Offset = update.UpdateID + 1
+				}
+			}
+		}
+	}()
+
+	return ch
 }
 
 func (tg *TelegramImpl) StopReceivingUpdates() {
-	tg.TgBot.StopReceivingUpdates()
+	tg.updatesMu.Lock()
+	defer tg.updatesMu.Unlock()
+
+	if tg.stopUpdates != nil {
+		close(tg.stopUpdates)
+		tg.stopUpdates = nil
+	}
 }
 
 func (tg *TelegramImpl) SendMessage(chatID int64, text string) (int, error) {
@@ -105,6 +160,11 @@ func (tg *TelegramImpl) DownloadMediaToTempFile(url string) (string, error) {
 		return "", err
 	}
 
+	return writeTempFile(data)
+}
+
+// writeTempFile stores data in a new file under tmp/ and returns its path.
+func writeTempFile(data []byte) (string, error) {
 	// Create tmp directory if it doesn't exist
 	if err := os.MkdirAll("tmp", os.ModePerm); err != nil {
 		return "", fmt.Errorf("failed to create tmp directory: %w", err)
@@ -153,10 +213,16 @@ func (tg *TelegramImpl) downloadMedia(url string) ([]byte, error) {
 	client := &http.Client{}
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("http client error: %w", err)
+		// Network failures are worth another attempt
+		return nil, apperrors.WrapWithCode(err, apperrors.CodeProviderDown, "http client error")
 	}
 	defer safeClose(resp.Body, tg.Logger)
-	if resp.StatusCode != http.StatusOK {
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return nil, apperrors.NewWithCode(apperrors.CodeRateLimited, "bad status code: 429")
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return nil, apperrors.NewWithCode(apperrors.CodeProviderDown, fmt.Sprintf("bad status code: %d", resp.StatusCode))
+	case resp.StatusCode != http.StatusOK:
 		return nil, fmt.Errorf("bad status code: %d", resp.StatusCode)
 	}
 	return io.ReadAll(resp.Body)
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Posts used to be saved without an ID; drop them so accounts are baselined with real shortcodes
+DELETE FROM post_parsers WHERE post_id = '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'deleted rows cannot be restored';
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A snapshot is stored whenever a tracked profile changes
+CREATE TABLE profile_snapshots (
+    id SERIAL PRIMARY KEY,
+    username VARCHAR NOT NULL,
+    full_name VARCHAR NOT NULL DEFAULT '',
+    biography TEXT NOT NULL DEFAULT '',
+    avatar_url VARCHAR NOT NULL DEFAULT '',
+    avatar BYTEA,
+    follower_count INTEGER NOT NULL DEFAULT 0,
+    following_count INTEGER NOT NULL DEFAULT 0,
+    post_count INTEGER NOT NULL DEFAULT 0,
+    is_verified BOOLEAN NOT NULL DEFAULT FALSE,
+    is_private BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+);
+
+CREATE INDEX idx_profile_snapshots_username_created_at ON profile_snapshots (username, created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE profile_snapshots;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Highlight albums listed by /highlights; inline buttons refer to them by id
+CREATE TABLE highlight_albums (
+    id SERIAL PRIMARY KEY,
+    username VARCHAR NOT NULL,
+    album_id VARCHAR NOT NULL,
+    title VARCHAR NOT NULL DEFAULT '',
+    cover_url VARCHAR NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    last_seen_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+);
+
+ALTER TABLE highlight_albums ADD CONSTRAINT unique_highlight_album UNIQUE(username, album_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE highlight_albums;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Highlight items seen by the highlight subscription job carry their album and item IDs;
+-- rows saved by manual downloads leave them empty
+ALTER TABLE highlights ADD COLUMN album_id VARCHAR NOT NULL DEFAULT '';
+ALTER TABLE highlights ADD COLUMN item_id VARCHAR NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX idx_highlights_username_item_id ON highlights (username, item_id) WHERE item_id <> '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_highlights_username_item_id;
+ALTER TABLE highlights DROP COLUMN item_id;
+ALTER TABLE highlights DROP COLUMN album_id;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Which checker recorded the post: the posts tab ('post') or the reels tab ('reel')
+ALTER TABLE post_parsers ADD COLUMN source VARCHAR(10) NOT NULL DEFAULT 'post';
+
+CREATE INDEX idx_post_parsers_username_source ON post_parsers (username, source);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_post_parsers_username_source;
+ALTER TABLE post_parsers DROP COLUMN source;
+-- +goose StatementEnd
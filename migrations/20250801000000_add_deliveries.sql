@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+-- One row per piece of content and chat, so dedupe and retries are decided per subscriber
+CREATE TABLE deliveries (
+    id SERIAL PRIMARY KEY,
+    content_type VARCHAR(10) NOT NULL,
+    content_id VARCHAR NOT NULL,
+    chat_id BIGINT NOT NULL,
+    status VARCHAR(10) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    message_id INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+);
+
+ALTER TABLE deliveries ADD CONSTRAINT unique_delivery UNIQUE(content_id, chat_id);
+
+CREATE INDEX idx_deliveries_created_at ON deliveries (created_at);
+
+-- Stories seen before this table existed were already sent to their subscribers at the time
+INSERT INTO deliveries (content_type, content_id, chat_id, status, attempts)
+SELECT 'story', sp.story_id, s.chat_id, 'sent', 1
+FROM story_parsers sp
+JOIN subscriptions s ON s.instagram_username = sp.username AND s.subscription_type IN ('story', 'all')
+ON CONFLICT DO NOTHING;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE deliveries;
+-- +goose StatementEnd
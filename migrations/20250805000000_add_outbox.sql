@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Notifications are queued here in the same transaction that records the content as seen
+CREATE TABLE outbox (
+    id SERIAL PRIMARY KEY,
+    content_type VARCHAR(10) NOT NULL,
+    content_id VARCHAR NOT NULL,
+    chat_id BIGINT NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+);
+
+ALTER TABLE outbox ADD CONSTRAINT unique_outbox_message UNIQUE(content_type, content_id, chat_id);
+
+CREATE INDEX idx_outbox_pending ON outbox (next_attempt_at) WHERE status = 'pending';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE outbox;
+-- +goose StatementEnd
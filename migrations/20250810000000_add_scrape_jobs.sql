@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Queue of account checks; finished jobs are deleted, so there is at most one job per account and type
+CREATE TABLE scrape_jobs (
+    id SERIAL PRIMARY KEY,
+    job_type VARCHAR(10) NOT NULL,
+    username VARCHAR NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'queued',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    timeout_seconds INTEGER NOT NULL,
+    run_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    locked_until TIMESTAMP WITH TIME ZONE,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+);
+
+ALTER TABLE scrape_jobs ADD CONSTRAINT unique_scrape_job UNIQUE(job_type, username);
+
+CREATE INDEX idx_scrape_jobs_run_at ON scrape_jobs (run_at);
+
+CREATE TABLE scrape_dead_jobs (
+    id SERIAL PRIMARY KEY,
+    job_type VARCHAR(10) NOT NULL,
+    username VARCHAR NOT NULL,
+    attempts INTEGER NOT NULL,
+    timeout_seconds INTEGER NOT NULL,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    failed_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+);
+
+CREATE INDEX idx_scrape_dead_jobs_failed_at ON scrape_dead_jobs (failed_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE scrape_dead_jobs;
+DROP TABLE scrape_jobs;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Per-account polling state; the interval is recomputed from recent activity on every poll
+CREATE TABLE account_polls (
+    username VARCHAR NOT NULL,
+    job_type VARCHAR(10) NOT NULL,
+    interval_seconds INTEGER NOT NULL,
+    items_seen INTEGER NOT NULL DEFAULT 0,
+    last_polled_at TIMESTAMP WITH TIME ZONE,
+    next_poll_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    PRIMARY KEY (username, job_type)
+);
+
+CREATE INDEX idx_account_polls_next_poll_at ON account_polls (next_poll_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE account_polls;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Whether the post was recorded by the first check of its account rather than found new
+ALTER TABLE post_parsers ADD COLUMN baseline BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Rows recorded with the first row of their checker were recorded as a baseline
+UPDATE post_parsers p
+SET baseline = TRUE
+WHERE p.created_at <= (
+    SELECT MIN(first.created_at) FROM post_parsers first
+    WHERE first.username = p.username AND first.source = p.source
+) + INTERVAL '1 minute';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE post_parsers DROP COLUMN baseline;
+-- +goose StatementEnd
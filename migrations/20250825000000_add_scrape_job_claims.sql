@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Number of times the job was claimed. A worker only completes or releases the job under the
+-- claim it got, so one whose visibility timeout expired can't touch a job another worker reclaimed.
+ALTER TABLE scrape_jobs ADD COLUMN claims INTEGER NOT NULL DEFAULT 0;
+
+UPDATE scrape_jobs SET claims = attempts;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE scrape_jobs DROP COLUMN claims;
+-- +goose StatementEnd
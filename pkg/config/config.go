@@ -22,12 +22,22 @@ type AppConfig struct {
 	LogLevel    string        `env:"LOG_LEVEL" envDefault:"info"`
 	Timeout     time.Duration `env:"TIMEOUT" envDefault:"30s"`
 	SentryUrl   string        `env:"SENTRY_URL" envDefault:""`
+	// Timezone is the location the scheduled jobs are planned in, e.g. the daily cleanup.
+	Timezone string `env:"TIMEZONE" envDefault:"Asia/Ho_Chi_Minh"`
+
+	// LeaderLockID is the Postgres advisory lock held by the replica that runs the schedulers
+	// and Telegram polling; replicas sharing a database must use the same ID.
+	LeaderLockID int64 `env:"LEADER_LOCK_ID" envDefault:"4721"`
+	// LeaderCheckInterval is how often standbys try to take the lock and the leader checks it still holds it.
+	LeaderCheckInterval time.Duration `env:"LEADER_CHECK_INTERVAL" envDefault:"10s"`
 }
 
 type TelegramConfig struct {
 	BotToken string `env:"BOT_TOKEN,required"`
 	User     int64  `env:"USER" envDefault:"0"`
 	Channel  string `env:"CHANNEL" envDefault:""`
+	// CommandTimeout bounds how long a single scraping command may run before it is aborted.
+	CommandTimeout time.Duration `env:"COMMAND_TIMEOUT" envDefault:"5m"`
 }
 
 type PostgresConfig struct {
@@ -48,7 +58,60 @@ type RedisConfig struct {
 }
 
 type ParserConfig struct {
-	PostCheckInterval string `env:"POST_CHECK_INTERVAL" envDefault:"@every 30m"`
+	ReelCheckInterval      string `env:"REEL_CHECK_INTERVAL" envDefault:"@every 1h"`
+	ProfileCheckInterval   string `env:"PROFILE_CHECK_INTERVAL" envDefault:"@every 3h"`
+	HighlightCheckInterval string `env:"HIGHLIGHT_CHECK_INTERVAL" envDefault:"@every 6h"`
+
+	// PollMinInterval and PollMaxInterval bound the per-account story and post poll intervals,
+	// which shrink for accounts that post often and grow for quiet ones.
+	PollMinInterval time.Duration `env:"POLL_MIN_INTERVAL" envDefault:"10m"`
+	PollMaxInterval time.Duration `env:"POLL_MAX_INTERVAL" envDefault:"6h"`
+
+	// ScrapeConcurrency caps the scraper calls in flight across commands and scheduled checks.
+	ScrapeConcurrency int `env:"SCRAPE_CONCURRENCY" envDefault:"3"`
+	// Scrape results are cached for these TTLs, per kind of call; zero disables caching of
+	// that kind. Identical calls in flight at the same time always share one scrape.
+	CacheStoriesTTL    time.Duration `env:"CACHE_STORIES_TTL" envDefault:"1m"`
+	CachePostsTTL      time.Duration `env:"CACHE_POSTS_TTL" envDefault:"2m"`
+	CacheHighlightsTTL time.Duration `env:"CACHE_HIGHLIGHTS_TTL" envDefault:"5m"`
+	CacheProfileTTL    time.Duration `env:"CACHE_PROFILE_TTL" envDefault:"5m"`
+	CacheMediaTTL      time.Duration `env:"CACHE_MEDIA_TTL" envDefault:"10m"`
+	CacheSnapshotTTL   time.Duration `env:"CACHE_SNAPSHOT_TTL" envDefault:"1m"`
+
+	// ScrapeWorkers is the number of workers taking account checks from the scrape job queue.
+	ScrapeWorkers int `env:"SCRAPE_WORKERS" envDefault:"5"`
+	// ScrapeJobMaxAttempts is how many times an account check is tried before it is dead-lettered.
+	ScrapeJobMaxAttempts int `env:"SCRAPE_JOB_MAX_ATTEMPTS" envDefault:"3"`
+
+	// OutboxDispatchInterval is how often queued notifications are sent.
+	OutboxDispatchInterval time.Duration `env:"OUTBOX_DISPATCH_INTERVAL" envDefault:"30s"`
+	// OutboxMaxAttempts is how many times a notification is tried before it is dead-lettered.
+	OutboxMaxAttempts int `env:"OUTBOX_MAX_ATTEMPTS" envDefault:"5"`
+
+	// Providers lists the instagram scraper providers in priority order.
+	Providers                []string      `env:"PROVIDERS" envSeparator:"," envDefault:"playwright"`
+	ProviderCooldown         time.Duration `env:"PROVIDER_COOLDOWN" envDefault:"5m"`
+	ProviderFailureThreshold int           `env:"PROVIDER_FAILURE_THRESHOLD" envDefault:"2"`
+
+	// HTTPBaseURL is the origin of the JSON endpoints used by the browser-free provider.
+	HTTPBaseURL string        `env:"HTTP_BASE_URL" envDefault:"https://instasupersave.com"`
+	HTTPTimeout time.Duration `env:"HTTP_TIMEOUT" envDefault:"30s"`
+
+	// BrowserPoolSize caps the number of browser contexts used concurrently by the Playwright provider.
+	BrowserPoolSize int `env:"BROWSER_POOL_SIZE" envDefault:"3"`
+	// BrowserContextMaxPages is the number of pages a context serves before it is recycled.
+	BrowserContextMaxPages int `env:"BROWSER_CONTEXT_MAX_PAGES" envDefault:"20"`
+
+	// ProfilePath points to the scraper profile with the site's URLs, selectors and timeouts.
+	ProfilePath string `env:"PROFILE_PATH" envDefault:"configs/scraper_profile.json"`
+	// ProfileReloadInterval is how often the profile file is checked for changes; 0 disables it.
+	ProfileReloadInterval time.Duration `env:"PROFILE_RELOAD_INTERVAL" envDefault:"1m"`
+
+	// CanaryAccounts are known public accounts scraped periodically to detect scraper breakage; empty disables the canary.
+	CanaryAccounts []string `env:"CANARY_ACCOUNTS" envSeparator:","`
+	CanaryInterval string   `env:"CANARY_INTERVAL" envDefault:"@every 6h"`
+	// CanaryReelURL is a known reel used to check GetUserReel; empty skips that check.
+	CanaryReelURL string `env:"CANARY_REEL_URL" envDefault:""`
 }
 
 func New() (*Config, error) {
@@ -16,6 +16,24 @@ var (
 	ErrServiceUnavailable = errors.New("service unavailable")
 )
 
+// Error codes of scraper failures
+const (
+	CodeNotFound        = "NOT_FOUND"
+	CodePrivate         = "PRIVATE"
+	CodeRateLimited     = "RATE_LIMITED"
+	CodeSelectorMissing = "SELECTOR_MISSING"
+	CodeTimeout         = "TIMEOUT"
+	CodeEmptyResult     = "EMPTY_RESULT"
+	CodeProviderDown    = "PROVIDER_DOWN"
+)
+
+// transientCodes are the codes of failures that may go away on their own, so retrying is worthwhile
+var transientCodes = map[string]bool{
+	CodeRateLimited:  true,
+	CodeTimeout:      true,
+	CodeProviderDown: true,
+}
+
 // Error represents a custom error type
 type Error struct {
 	Code    string
@@ -43,6 +61,14 @@ func New(message string) error {
 	}
 }
 
+// NewWithCode creates a new error with a code and message
+func NewWithCode(code, message string) error {
+	return &Error{
+		Code:    code,
+		Message: message,
+	}
+}
+
 // Wrap wraps an error with additional message
 func Wrap(err error, message string) error {
 	if err == nil {
@@ -126,3 +152,15 @@ func IsBadRequest(err error) bool {
 func IsServiceUnavailable(err error) bool {
 	return errors.Is(err, ErrServiceUnavailable)
 }
+
+// IsTransient returns true if the error has a code of a failure that may go away on retry
+func IsTransient(err error) bool {
+	return transientCodes[GetCode(err)]
+}
+
+// IsPermanent returns true if the error has a code of a failure that would happen again on
+// retry. Errors without a code are neither transient nor permanent.
+func IsPermanent(err error) bool {
+	code := GetCode(err)
+	return code != "" && !transientCodes[code]
+}
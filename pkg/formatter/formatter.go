@@ -1,8 +1,10 @@
 package formatter
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // FormatNumber converts an integer to a string with commas as thousands separators.
@@ -55,3 +57,9 @@ func EscapeMarkdownV2(s string) string {
 	}
 	return sb.String()
 }
+
+// StoryCaption builds the caption sent with a story or highlight item.
+// Example: "📅 @user · 10 May 2024 14:05 UTC"
+func StoryCaption(username string, takenAt time.Time) string {
+	return fmt.Sprintf("📅 @%s · %s", username, takenAt.UTC().Format("02 Jan 2006 15:04 MST"))
+}
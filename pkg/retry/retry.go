@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
+	"github.com/orgball2608/insta-parser-telegram-bot/pkg/errors"
 	"github.com/orgball2608/insta-parser-telegram-bot/pkg/logger"
 )
 
@@ -43,7 +44,7 @@ func Do(ctx context.Context, log logger.Logger, operationName string, operation
 		)
 	}
 
-	return backoff.RetryNotify(operation, retryableWithContext, notify)
+	return backoff.RetryNotify(retryTransient(operation), retryableWithContext, notify)
 }
 
 func RetryWithCustomNotify(ctx context.Context, operationName string, operation func() error, cfg Config, notify func(error, time.Duration)) error {
@@ -56,5 +57,18 @@ func RetryWithCustomNotify(ctx context.Context, operationName string, operation
 	retryable := backoff.WithMaxRetries(bo, cfg.MaxRetries)
 	retryableWithContext := backoff.WithContext(retryable, ctx)
 
-	return backoff.RetryNotify(operation, retryableWithContext, notify)
+	return backoff.RetryNotify(retryTransient(operation), retryableWithContext, notify)
+}
+
+// retryTransient ends the retries as soon as the operation fails with an error that is not
+// known to be transient. Operations that want to be retried must tag their errors with a
+// transient code.
+func retryTransient(operation func() error) func() error {
+	return func() error {
+		err := operation()
+		if err != nil && !errors.IsTransient(err) {
+			return backoff.Permanent(err)
+		}
+		return err
+	}
 }